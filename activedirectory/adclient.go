@@ -4,14 +4,21 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
+// securityDescriptorMutexKV serializes read-modify-write of nTSecurityDescriptor per object,
+// resources sharing the connection would otherwise overwrite each other's ACEs.
+var securityDescriptorMutexKV = mutexkv.NewMutexKV()
+
 // ADClient is used to make AD connections
 type ADClient struct {
 	logger        hclog.Logger
@@ -19,16 +26,43 @@ type ADClient struct {
 	conn          *ldap.Conn
 	mux           sync.Mutex
 	activeWorkers int
+	// confidential is true if connection is encrypted, AD accepts password writes only over encrypted connection
+	confidential bool
 }
 
+// errConfidentialConnRequired is returned before password is written over unencrypted connection
+var errConfidentialConnRequired = errors.New("AD accepts passwords only over encrypted connection, use ldaps:// in provider 'ldap_url' or set provider 'start_tls' to true")
+
+// extendedDNControlOID is LDAP_SERVER_EXTENDED_DN_OID control, when used DN values are returned
+// in format <GUID=guid>;<SID=sid>;dn
+const extendedDNControlOID = "1.2.840.113556.1.4.529"
+
+// extendedDNControlValue is BER encoded ExtendedDNRequestValue SEQUENCE { Flag INTEGER 1 },
+// flag 1 returns GUID and SID in string format instead of hexadecimal.
+var extendedDNControlValue = string([]byte{0x30, 0x03, 0x02, 0x01, 0x01})
+
+// sdFlagsControlOID is LDAP_SERVER_SD_FLAGS_OID control, it selects parts of nTSecurityDescriptor
+// which are read or written. used with DACL_SECURITY_INFORMATION so that owner, group and SACL are left untouched.
+const sdFlagsControlOID = "1.2.840.113556.1.4.801"
+
+// sdFlagsControlDACLValue is BER encoded SDFlagsRequestValue SEQUENCE { Flags INTEGER 4 }
+var sdFlagsControlDACLValue = string([]byte{0x30, 0x03, 0x02, 0x01, 0x04})
+
 // Config represents AD config
 type Config struct {
 	serverURL   string
 	domain      string
+	domainDN    string
 	topDN       string
 	username    string
 	password    string
 	insecureTLS bool
+	startTLS    bool
+}
+
+// isConfidential checks if connections of the config are encrypted either by LDAPS or StartTLS.
+func (c Config) isConfidential() bool {
+	return strings.HasPrefix(strings.ToLower(c.serverURL), "ldaps://") || c.startTLS
 }
 
 // initialiseConn will start AD connection and bind with given username. it will also keep tract of number of workers using connection.
@@ -54,7 +88,21 @@ func (c *ADClient) initialiseConn() error {
 		return fmt.Errorf("ADClient.initialiseConn: unable to connect to ad server err:%w", err)
 	}
 
+	if _, ok := c.conn.TLSConnectionState(); !ok && c.config.startTLS {
+		c.logger.Debug("ADClient.initialiseConn: upgrading connection using StartTLS")
+		serverName, err := tlsServerName(c.config.serverURL)
+		if err != nil {
+			c.conn.Close()
+			return fmt.Errorf("ADClient.initialiseConn: unable to get server name err:%w", err)
+		}
+		if err := c.conn.StartTLS(&tls.Config{InsecureSkipVerify: c.config.insecureTLS, ServerName: serverName}); err != nil {
+			c.conn.Close()
+			return fmt.Errorf("ADClient.initialiseConn: StartTLS err:%w", err)
+		}
+	}
+
 	_, ok := c.conn.TLSConnectionState()
+	c.confidential = ok
 	c.logger.Debug("ADClient.initialiseConn: TLS Connection state", "TLS", ok)
 
 	// Bind AD user for LDAP operations
@@ -67,6 +115,18 @@ func (c *ADClient) initialiseConn() error {
 	return nil
 }
 
+// requireConfidentialConn returns error if connection is not encrypted, it's used before password is written
+// so that user gets actionable error instead of unwillingToPerform from AD.
+func (c *ADClient) requireConfidentialConn() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if !c.confidential {
+		return fmt.Errorf("connection to %s is not encrypted: %w", c.config.serverURL, errConfidentialConnRequired)
+	}
+	return nil
+}
+
 // done will keep track of active worker and close connection when no one is using it.
 func (c *ADClient) done() {
 	c.mux.Lock()
@@ -166,6 +226,571 @@ func getObjectsBySAM(c *ADClient, sam string) ([]*ldap.Entry, error) {
 	return sr.Entries, nil
 }
 
+func getObjectsByUPN(c *ADClient, upn string) ([]*ldap.Entry, error) {
+	sReq := &ldap.SearchRequest{
+		BaseDN:       c.config.topDN,
+		Scope:        ldap.ScopeWholeSubtree,
+		DerefAliases: ldap.NeverDerefAliases,
+		SizeLimit:    0,
+		TimeLimit:    0,
+		TypesOnly:    false,
+		Filter:       "(userPrincipalName=" + ldap.EscapeFilter(upn) + ")",
+		Attributes:   []string{"*"},
+		Controls:     nil,
+	}
+
+	sr, err := c.conn.Search(sReq)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, 32) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return sr.Entries, nil
+}
+
+func getObjectBySID(c *ADClient, sid string) (*ldap.Entry, error) {
+	rawSID, err := encodeSID(sid)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode SID:%s err:%w", sid, err)
+	}
+
+	sReq := &ldap.SearchRequest{
+		BaseDN:       c.config.topDN,
+		Scope:        ldap.ScopeWholeSubtree,
+		DerefAliases: ldap.NeverDerefAliases,
+		SizeLimit:    0,
+		TimeLimit:    0,
+		TypesOnly:    false,
+		Filter:       "(objectSid=" + parseID(rawSID) + ")",
+		Attributes:   []string{"*"},
+		Controls:     nil,
+	}
+
+	sr, err := c.conn.Search(sReq)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, 32) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	if len(sr.Entries) == 0 {
+		return nil, ErrObjectNotFound
+	}
+
+	if len(sr.Entries) > 1 {
+		return nil, fmt.Errorf("multiple ldap object found for SID: %s", sid)
+	}
+	return sr.Entries[0], nil
+}
+
+// getObjectDN returns dn of the object referenced by dn, GUID, SID, userPrincipalName or sAMAccountName.
+// dn is returned as it is without checking if object exists.
+func getObjectDN(c *ADClient, identity string) (string, error) {
+	var e *ldap.Entry
+	var entries []*ldap.Entry
+	var err error
+
+	switch {
+	case isGUIDString(identity):
+		var id string
+		if id, err = encodeGUID(identity); err != nil {
+			return "", fmt.Errorf("unable to encode GUID:%v err:%w", identity, err)
+		}
+		e, err = getObjectByID(c, id)
+	case isSIDString(identity):
+		e, err = getObjectBySID(c, identity)
+	case isDNString(identity):
+		return identity, nil
+	case isUPNString(identity):
+		entries, err = getObjectsByUPN(c, identity)
+		e, err = singleEntry(entries, err, "userPrincipalName", identity)
+	default:
+		entries, err = getObjectsBySAM(c, ldap.EscapeFilter(identity))
+		e, err = singleEntry(entries, err, "sAMAccountName", identity)
+	}
+	if err != nil {
+		return "", err
+	}
+	return e.DN, nil
+}
+
+// singleEntry makes sure search by attribute value returned exactly one object.
+func singleEntry(entries []*ldap.Entry, err error, attribute, value string) (*ldap.Entry, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrObjectNotFound
+	}
+	if len(entries) > 1 {
+		var dns []string
+		for _, e := range entries {
+			dns = append(dns, e.DN)
+		}
+		return nil, fmt.Errorf("multiple ldap object found for %s: %s dns: %s", attribute, value, dns)
+	}
+	return entries[0], nil
+}
+
+// getNameIdentityDNs returns dn of objects referenced by userPrincipalName or sAMAccountName keyed by lowercase identity,
+// identities of objects which doesn't exist are ignored.
+func getNameIdentityDNs(c *ADClient, identities []string) (map[string]string, error) {
+	resolved := map[string]string{}
+	for _, i := range identities {
+		if isGUIDString(i) || isSIDString(i) || isDNString(i) {
+			continue
+		}
+		dn, err := getObjectDN(c, i)
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to get dn of object:%s err:%w", i, err)
+		}
+		resolved[strings.ToLower(i)] = dn
+	}
+	return resolved, nil
+}
+
+// getObjectDNs resolves given identities to dns, identities of objects which doesn't exist are returned in notFound.
+func getObjectDNs(c *ADClient, identities []string) (dns []string, notFound []string, err error) {
+	for _, i := range identities {
+		dn, err := getObjectDN(c, i)
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				notFound = append(notFound, i)
+				continue
+			}
+			return nil, nil, fmt.Errorf("unable to get dn of object:%s err:%w", i, err)
+		}
+		dns = append(dns, dn)
+	}
+	return dns, notFound, nil
+}
+
+// getExtendedDNAttributeValues returns values of given dn syntax attribute using extended dn control,
+// each value is in format <GUID=guid>;<SID=sid>;dn
+func getExtendedDNAttributeValues(conn *ldap.Conn, dn, attribute string) ([]string, error) {
+	sReq := &ldap.SearchRequest{
+		BaseDN:       dn,
+		Scope:        ldap.ScopeBaseObject,
+		DerefAliases: ldap.NeverDerefAliases,
+		Filter:       "(objectClass=*)",
+		Attributes:   []string{attribute},
+		Controls:     []ldap.Control{ldap.NewControlString(extendedDNControlOID, true, extendedDNControlValue)},
+	}
+
+	sr, err := conn.Search(sReq)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, 32) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	if len(sr.Entries) == 0 {
+		return nil, ErrObjectNotFound
+	}
+	return sr.Entries[0].GetAttributeValues(attribute), nil
+}
+
+// setPrimaryGroup adds object to the group referenced by dn or SID and sets the group as object's primary group.
+func setPrimaryGroup(c *ADClient, objectDN, group string) error {
+	var e *ldap.Entry
+	var err error
+	if isSIDString(group) {
+		e, err = getObjectBySID(c, group)
+	} else {
+		e, err = getObjectByDN(c.conn, group)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to search primary group:%s err:%w", group, err)
+	}
+
+	sid, err := decodeSID(e.GetRawAttributeValue("objectSid"))
+	if err != nil {
+		return fmt.Errorf("unable to decode SID of primary group:%s err:%w", e.DN, err)
+	}
+	_, rid, err := splitSID(sid)
+	if err != nil {
+		return fmt.Errorf("unable to get RID of primary group:%s err:%w", e.DN, err)
+	}
+
+	// object must be a member of the group before it can be set as primary group
+	if err := addObjectToGroup(c.conn, e.DN, objectDN); err != nil {
+		return fmt.Errorf("unable to add object to primary group:%s err:%w", e.DN, err)
+	}
+
+	modReq := &ldap.ModifyRequest{DN: objectDN}
+	modReq.Replace("primaryGroupID", []string{rid})
+	if err := c.conn.Modify(modReq); err != nil {
+		return fmt.Errorf("unable to set primaryGroupID:%s of object:%s err:%w", rid, objectDN, err)
+	}
+	return nil
+}
+
+// getPrimaryGroup returns primary group of the object as SID if configured value is SID otherwise as dn.
+func getPrimaryGroup(c *ADClient, e *ldap.Entry, configured string) (string, error) {
+	sid, err := decodeSID(e.GetRawAttributeValue("objectSid"))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode SID of object:%s err:%w", e.DN, err)
+	}
+	domainSID, _, err := splitSID(sid)
+	if err != nil {
+		return "", err
+	}
+	groupSID := domainSID + "-" + e.GetAttributeValue("primaryGroupID")
+	if isSIDString(configured) {
+		return groupSID, nil
+	}
+
+	g, err := getObjectBySID(c, groupSID)
+	if err != nil {
+		return "", fmt.Errorf("unable to search primary group SID:%s err:%w", groupSID, err)
+	}
+	return g.DN, nil
+}
+
+// getManager returns manager of the object, GUID of the manager is returned if configured value is GUID otherwise its dn.
+func getManager(c *ADClient, e *ldap.Entry, configured string) (string, error) {
+	if e.GetAttributeValue("manager") == "" {
+		return "", nil
+	}
+	if !isGUIDString(configured) {
+		return e.GetAttributeValue("manager"), nil
+	}
+	values, err := getExtendedDNAttributeValues(c.conn, e.DN, "manager")
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+	return parseExtendedDN(values[0]).guid, nil
+}
+
+// getObjectAttributes reads given attributes of the object, it's used for constructed attributes
+// which are not returned when all attributes are requested.
+func getObjectAttributes(conn *ldap.Conn, dn string, attributes []string) (*ldap.Entry, error) {
+	sReq := &ldap.SearchRequest{
+		BaseDN:       dn,
+		Scope:        ldap.ScopeBaseObject,
+		DerefAliases: ldap.NeverDerefAliases,
+		Filter:       "(objectClass=*)",
+		Attributes:   attributes,
+	}
+
+	sr, err := conn.Search(sReq)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, 32) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	if len(sr.Entries) == 0 {
+		return nil, ErrObjectNotFound
+	}
+	return sr.Entries[0], nil
+}
+
+// getDomainLockoutDuration reads lockoutDuration of the domain password policy in 100 nanoseconds intervals.
+func getDomainLockoutDuration(c *ADClient) (int64, error) {
+	e, err := getObjectAttributes(c.conn, c.config.domainDN, []string{"lockoutDuration"})
+	if err != nil {
+		return 0, fmt.Errorf("unable to read domain object:%s err:%w", c.config.domainDN, err)
+	}
+	v := e.GetAttributeValue("lockoutDuration")
+	d, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse lockoutDuration value %s to int", v)
+	}
+	return d, nil
+}
+
+// domainPasswordComplex is DOMAIN_PASSWORD_COMPLEX flag of pwdProperties attribute
+const domainPasswordComplex = 1
+
+// domainPasswordStoreCleartext is DOMAIN_PASSWORD_STORE_CLEARTEXT flag of pwdProperties attribute
+const domainPasswordStoreCleartext = 16
+
+// passwordPolicy represents password settings applied to the account.
+type passwordPolicy struct {
+	dn         string
+	minLength  int
+	complexity bool
+}
+
+// getDomainPasswordPolicy reads password settings of the domain password policy.
+func getDomainPasswordPolicy(c *ADClient) (*passwordPolicy, error) {
+	e, err := getObjectAttributes(c.conn, c.config.domainDN, []string{"minPwdLength", "pwdProperties"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read domain object:%s err:%w", c.config.domainDN, err)
+	}
+	minLength, err := strconv.Atoi(e.GetAttributeValue("minPwdLength"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse minPwdLength value %s to int", e.GetAttributeValue("minPwdLength"))
+	}
+	properties, err := strconv.Atoi(e.GetAttributeValue("pwdProperties"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pwdProperties value %s to int", e.GetAttributeValue("pwdProperties"))
+	}
+	return &passwordPolicy{dn: c.config.domainDN, minLength: minLength, complexity: properties&domainPasswordComplex != 0}, nil
+}
+
+// getUserPasswordPolicy reads password settings applied to the user, fine-grained password policy
+// (msDS-ResultantPSO) is used for existing user if it's set otherwise domain password policy is used.
+func getUserPasswordPolicy(c *ADClient, guid string) (*passwordPolicy, error) {
+	if guid == "" {
+		return getDomainPasswordPolicy(c)
+	}
+	id, err := encodeGUID(guid)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode GUID:%v err:%w", guid, err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search user with ID GUID:%v err:%w", guid, err)
+	}
+	ue, err := getObjectAttributes(c.conn, e.DN, []string{"msDS-ResultantPSO"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read msDS-ResultantPSO of user:%s err:%w", e.DN, err)
+	}
+	pso := ue.GetAttributeValue("msDS-ResultantPSO")
+	if pso == "" {
+		return getDomainPasswordPolicy(c)
+	}
+	pe, err := getObjectAttributes(c.conn, pso, []string{"msDS-MinimumPasswordLength", "msDS-PasswordComplexityEnabled"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read password settings object:%s err:%w", pso, err)
+	}
+	minLength, err := strconv.Atoi(pe.GetAttributeValue("msDS-MinimumPasswordLength"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse msDS-MinimumPasswordLength value %s to int", pe.GetAttributeValue("msDS-MinimumPasswordLength"))
+	}
+	return &passwordPolicy{dn: pso, minLength: minLength, complexity: strings.EqualFold(pe.GetAttributeValue("msDS-PasswordComplexityEnabled"), "TRUE")}, nil
+}
+
+// getObjectSecurityDescriptor reads DACL part of nTSecurityDescriptor of the object.
+func getObjectSecurityDescriptor(conn *ldap.Conn, dn string) (*securityDescriptor, error) {
+	sReq := &ldap.SearchRequest{
+		BaseDN:       dn,
+		Scope:        ldap.ScopeBaseObject,
+		DerefAliases: ldap.NeverDerefAliases,
+		Filter:       "(objectClass=*)",
+		Attributes:   []string{"nTSecurityDescriptor"},
+		Controls:     []ldap.Control{ldap.NewControlString(sdFlagsControlOID, true, sdFlagsControlDACLValue)},
+	}
+
+	sr, err := conn.Search(sReq)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, 32) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	if len(sr.Entries) == 0 {
+		return nil, ErrObjectNotFound
+	}
+	raw := sr.Entries[0].GetRawAttributeValue("nTSecurityDescriptor")
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("nTSecurityDescriptor of object:%s is not readable", dn)
+	}
+	return decodeSecurityDescriptor(raw)
+}
+
+// setObjectSecurityDescriptor writes DACL part of given security descriptor to the object.
+func setObjectSecurityDescriptor(conn *ldap.Conn, dn string, sd *securityDescriptor) error {
+	raw, err := sd.encode()
+	if err != nil {
+		return err
+	}
+	modReq := ldap.NewModifyRequest(dn, []ldap.Control{ldap.NewControlString(sdFlagsControlOID, true, sdFlagsControlDACLValue)})
+	modReq.Replace("nTSecurityDescriptor", []string{string(raw)})
+	return conn.Modify(modReq)
+}
+
+// securityDescriptorLockKey returns normalized dn of the object used as key of securityDescriptorMutexKV.
+func securityDescriptorLockKey(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(dn)
+	}
+	var rdns []string
+	for _, rdn := range parsed.RDNs {
+		var attrs []string
+		for _, a := range rdn.Attributes {
+			attrs = append(attrs, strings.ToLower(a.Type)+"="+strings.ToLower(a.Value))
+		}
+		rdns = append(rdns, strings.Join(attrs, "+"))
+	}
+	return strings.Join(rdns, ",")
+}
+
+// updateObjectSecurityDescriptor reads DACL of the object, passes it to modify and writes it back if modify
+// reports a change. The object is locked for the whole read-modify-write.
+func updateObjectSecurityDescriptor(conn *ldap.Conn, dn string, modify func(sd *securityDescriptor) (bool, error)) error {
+	key := securityDescriptorLockKey(dn)
+	securityDescriptorMutexKV.Lock(key)
+	defer securityDescriptorMutexKV.Unlock(key)
+
+	sd, err := getObjectSecurityDescriptor(conn, dn)
+	if err != nil {
+		return fmt.Errorf("unable to read security descriptor of object:%s err:%w", dn, err)
+	}
+	changed, err := modify(sd)
+	if err != nil || !changed {
+		return err
+	}
+	if err := setObjectSecurityDescriptor(conn, dn, sd); err != nil {
+		return fmt.Errorf("unable to update security descriptor of object:%s err:%w", dn, err)
+	}
+	return nil
+}
+
+// getObjectSIDs returns map of identities to SIDs, identity can be either SID or DN of the object.
+func getObjectSIDs(c *ADClient, identities []string) (map[string]string, error) {
+	sids := map[string]string{}
+	for _, identity := range identities {
+		if isSIDString(identity) {
+			sids[identity] = identity
+			continue
+		}
+		e, err := getObjectAttributes(c.conn, identity, []string{"objectSid"})
+		if err != nil {
+			return nil, fmt.Errorf("unable to find object:%s err:%w", identity, err)
+		}
+		sid, err := decodeSID(e.GetRawAttributeValue("objectSid"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode SID of object:%s err:%w", identity, err)
+		}
+		sids[identity] = sid
+	}
+	return sids, nil
+}
+
+// getGroupMSAMembership returns SIDs which are allowed to retrieve managed password of the gMSA.
+func getGroupMSAMembership(conn *ldap.Conn, dn string) ([]string, error) {
+	e, err := getObjectAttributes(conn, dn, []string{"msDS-GroupMSAMembership"})
+	if err != nil {
+		return nil, err
+	}
+	raw := e.GetRawAttributeValue("msDS-GroupMSAMembership")
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	sd, err := decodeSecurityDescriptor(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode msDS-GroupMSAMembership of object:%s err:%w", dn, err)
+	}
+	return groupMSAMembershipSIDs(sd), nil
+}
+
+// encodeGroupMSAMembership returns msDS-GroupMSAMembership value which allows SIDs to retrieve managed password.
+func encodeGroupMSAMembership(sids map[string]string) (string, error) {
+	var values []string
+	for _, sid := range sids {
+		values = append(values, sid)
+	}
+	sort.Strings(values)
+	sd, err := groupMSAMembershipSecurityDescriptor(values)
+	if err != nil {
+		return "", err
+	}
+	raw, err := sd.encode()
+	if err != nil {
+		return "", fmt.Errorf("unable to encode msDS-GroupMSAMembership err:%w", err)
+	}
+	return string(raw), nil
+}
+
+// getHostComputer returns the computer which is linked to standalone managed service account by msDS-HostServiceAccount,
+// configured value is returned if it matches linked computer otherwise DN or GUID is returned based on configured format.
+func getHostComputer(c *ADClient, dn, configured string) (string, error) {
+	values, err := getExtendedDNAttributeValues(c.conn, dn, "msDS-HostServiceAccountBL")
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+	for _, v := range values {
+		if configured != "" && parseExtendedDN(v).matches(configured) {
+			return configured, nil
+		}
+	}
+	host := parseExtendedDN(values[0])
+	if isGUIDString(configured) {
+		return host.guid, nil
+	}
+	return host.dn, nil
+}
+
+// setHostServiceAccount links standalone managed service account to the computer using msDS-HostServiceAccount
+// attribute of the computer if link is true otherwise the link is removed.
+func setHostServiceAccount(c *ADClient, computer, msaDN string, link bool) error {
+	computerDN, err := getObjectDN(c, computer)
+	if err != nil {
+		return fmt.Errorf("unable to find computer:%s err:%w", computer, err)
+	}
+	modReq := ldap.NewModifyRequest(computerDN, nil)
+	if link {
+		modReq.Add("msDS-HostServiceAccount", []string{msaDN})
+	} else {
+		modReq.Delete("msDS-HostServiceAccount", []string{msaDN})
+	}
+	if err := c.conn.Modify(modReq); err != nil {
+		// 16 (noSuchAttribute) means the link doesn't exist and 20 (attributeOrValueExists) means it's already set
+		if (!link && ldap.IsErrorWithCode(err, 16)) || (link && ldap.IsErrorWithCode(err, 20)) {
+			return nil
+		}
+		return fmt.Errorf("unable to update msDS-HostServiceAccount of computer:%s err:%w", computerDN, err)
+	}
+	return nil
+}
+
+// getCannotChangePassword checks if object's DACL denies 'Change Password' extended right.
+func getCannotChangePassword(conn *ldap.Conn, dn string) (bool, error) {
+	sd, err := getObjectSecurityDescriptor(conn, dn)
+	if err != nil {
+		return false, err
+	}
+	return isChangePasswordDenied(sd.dacl), nil
+}
+
+// setCannotChangePassword adds or removes deny ACEs of 'Change Password' extended right to object's DACL.
+func setCannotChangePassword(conn *ldap.Conn, dn string, deny bool) error {
+	return updateObjectSecurityDescriptor(conn, dn, func(sd *securityDescriptor) (bool, error) {
+		if sd.dacl == nil {
+			return false, fmt.Errorf("object:%s doesn't have DACL", dn)
+		}
+		if isChangePasswordDenied(sd.dacl) == deny {
+			return false, nil
+		}
+		setChangePasswordDenied(sd.dacl, deny)
+		return true, nil
+	})
+}
+
+// renameObject renames the object and moves it to newOU, rdnAttr is the attribute of object's RDN ie 'cn'.
+func renameObject(c *ADClient, rdnAttr, oldName, oldOU, newName, newOU string) error {
+	c.logger.Debug("renameObject: Name Changes", "old", oldName, "new", newName)
+	c.logger.Debug("renameObject: OU Changes", "old", oldOU, "new", newOU)
+
+	req := &ldap.ModifyDNRequest{
+		DN:           rdnAttr + "=" + oldName + "," + oldOU,
+		NewRDN:       rdnAttr + "=" + newName,
+		DeleteOldRDN: true,
+		NewSuperior:  newOU,
+	}
+	if err := c.conn.ModifyDN(req); err != nil {
+		return fmt.Errorf("unable to update dn of LDAP object: ModifyDNRequest:%v err:%w", req, err)
+	}
+	c.logger.Info("renameObject: object DN modified", "NewRDN", req.NewRDN, "newOU", req.NewSuperior)
+	return nil
+}
+
 func addObject(conn *ldap.Conn, addReq *ldap.AddRequest) (string, error) {
 	if err := conn.Add(addReq); err != nil {
 		return "", fmt.Errorf("addObject: unable to add object dn:%v to LDAP server err:%w", addReq.DN, err)
@@ -2,15 +2,23 @@ package activedirectory
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"net/url"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
@@ -20,6 +28,18 @@ import (
 // ErrObjectNotFound is custom error for object not found
 var ErrObjectNotFound = errors.New("LDAP object not found")
 
+var (
+	guidStringRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	sidStringRegexp  = regexp.MustCompile(`^[sS]-1-[0-9]+(-[0-9]+)*$`)
+	upnStringRegexp  = regexp.MustCompile(`^[^@]+@[^@]+$`)
+	driveRegexp      = regexp.MustCompile(`^[a-zA-Z]:$`)
+	uncPathRegexp    = regexp.MustCompile(`^\\\\[^\\/:*?"<>|]+\\[^\\/:*?"<>|]+(\\[^\\/:*?"<>|]+)*\\?$`)
+	attrNameRegexp   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+)
+
+// samInvalidChars are characters not allowed in sAMAccountName
+const samInvalidChars = `"/\[]:;|=,+*?<>@`
+
 const (
 	accountDisabledFlag  uint64 = 2
 	globalScopeFlag      uint64 = 2
@@ -28,6 +48,86 @@ const (
 	securityGroupFlag    uint64 = 2147483648
 )
 
+// userAccountControl flags
+// https://docs.microsoft.com/en-us/troubleshoot/windows-server/identity/useraccountcontrol-manipulate-account-properties
+const (
+	passwordNotRequiredFlag        uint64 = 0x20
+	dontExpirePasswordFlag         uint64 = 0x10000
+	smartcardRequiredFlag          uint64 = 0x40000
+	trustedForDelegationFlag       uint64 = 0x80000
+	notDelegatedFlag               uint64 = 0x100000
+	useDESKeyOnlyFlag              uint64 = 0x200000
+	dontRequirePreauthFlag         uint64 = 0x400000
+	trustedToAuthForDelegationFlag uint64 = 0x1000000
+)
+
+// uacFlagArguments maps typed boolean arguments of user and computer resources to userAccountControl flags.
+// 'cannot_change_password' is not part of the map since it's controlled by ACE and not by userAccountControl.
+var uacFlagArguments = map[string]uint64{
+	"password_never_expires":         dontExpirePasswordFlag,
+	"password_not_required":          passwordNotRequiredFlag,
+	"smartcard_required":             smartcardRequiredFlag,
+	"trusted_for_delegation":         trustedForDelegationFlag,
+	"trusted_to_auth_for_delegation": trustedToAuthForDelegationFlag,
+	"not_delegated":                  notDelegatedFlag,
+	"use_des_key_only":               useDESKeyOnlyFlag,
+	"dont_require_preauth":           dontRequirePreauthFlag,
+}
+
+// userStringAttributes maps typed string arguments of user resource to LDAP attributes
+var userStringAttributes = map[string]string{
+	"mail":             "mail",
+	"display_name":     "displayName",
+	"initials":         "initials",
+	"title":            "title",
+	"department":       "department",
+	"company":          "company",
+	"office":           "physicalDeliveryOfficeName",
+	"telephone_number": "telephoneNumber",
+	"mobile":           "mobile",
+	"employee_id":      "employeeID",
+	"employee_type":    "employeeType",
+	"street":           "streetAddress",
+	"city":             "l",
+	"state":            "st",
+	"postal_code":      "postalCode",
+	"home_page":        "wWWHomePage",
+	"home_directory":   "homeDirectory",
+	"home_drive":       "homeDrive",
+	"profile_path":     "profilePath",
+	"script_path":      "scriptPath",
+}
+
+// contactStringAttributes maps typed string arguments of contact resource to LDAP attributes
+var contactStringAttributes = map[string]string{
+	"mail":             "mail",
+	"display_name":     "displayName",
+	"initials":         "initials",
+	"title":            "title",
+	"department":       "department",
+	"company":          "company",
+	"telephone_number": "telephoneNumber",
+	"mobile":           "mobile",
+}
+
+// maxUserWorkstationsLength is max length of userWorkstations attribute value
+const maxUserWorkstationsLength = 1024
+
+// countryAttributes are LDAP attributes updated by 'country' argument
+var countryAttributes = []string{"c", "co", "countryCode"}
+
+// fileTimeNever is max value of FILETIME, it's used by AD for values which never expire
+const fileTimeNever int64 = math.MaxInt64
+
+// fileTimeUnixEpoch is FILETIME value (100ns intervals since 1601-01-01 UTC) of unix epoch
+const fileTimeUnixEpoch int64 = 116444736000000000
+
+// accountExpiresNever is value of 'account_expires' argument for accounts which never expire
+const accountExpiresNever = "never"
+
+// memberBatchSize is the max number of values added or removed from 'member' attribute in a single modify request.
+const memberBatchSize = 500
+
 func ignoreCaseDiffSuppressor(k, old, new string, d *schema.ResourceData) bool {
 	return strings.EqualFold(old, new)
 }
@@ -36,6 +136,22 @@ func lowercaseHashString(v interface{}) int {
 	return hashcode.String(strings.ToLower(v.(string)))
 }
 
+func setToStringList(set *schema.Set) []string {
+	var list []string
+	for _, v := range set.List() {
+		list = append(list, v.(string))
+	}
+	return list
+}
+
+func stringListToInterfaces(list []string) []interface{} {
+	values := make([]interface{}, len(list))
+	for i, v := range list {
+		values[i] = v
+	}
+	return values
+}
+
 func validateAttributesJSON(val interface{}, key string) ([]string, []error) {
 	var errs []error
 	var warns []string
@@ -72,14 +188,143 @@ func normalizeAttributesJSON(val interface{}) string {
 	return string(ret)
 }
 
+func isGUIDString(s string) bool {
+	return guidStringRegexp.MatchString(s)
+}
+
+func isSIDString(s string) bool {
+	return sidStringRegexp.MatchString(s)
+}
+
+func isDNString(s string) bool {
+	return strings.Contains(s, "=")
+}
+
+func isUPNString(s string) bool {
+	return !isDNString(s) && upnStringRegexp.MatchString(s)
+}
+
+// validateObjectIdentity makes sure value is a dn, GUID, SID, userPrincipalName or sAMAccountName of an object.
+func validateObjectIdentity(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	switch {
+	case isGUIDString(v), isSIDString(v), isUPNString(v):
+	case isDNString(v):
+		// ParseDN ignores empty trailing RDN
+		if _, err := ldap.ParseDN(v); err != nil || strings.HasSuffix(strings.TrimSpace(v), ",") {
+			errs = append(errs, fmt.Errorf("%q entry should be valid DN, got value:%s err:%v", key, v, err))
+		}
+	case v == "" || strings.ContainsAny(v, samInvalidChars):
+		errs = append(errs, fmt.Errorf("%q entry should be valid DN, GUID, SID, userPrincipalName or sAMAccountName, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// extendedDN represents value returned by AD when extended dn control is used.
+type extendedDN struct {
+	guid string
+	sid  string
+	dn   string
+}
+
+// parseExtendedDN parses value in format <GUID=guid>;<SID=sid>;dn, SID is only present for security principals.
+func parseExtendedDN(v string) extendedDN {
+	var e extendedDN
+	for strings.HasPrefix(v, "<") {
+		end := strings.Index(v, ">")
+		if end == -1 {
+			break
+		}
+		kv := strings.SplitN(v[1:end], "=", 2)
+		if len(kv) == 2 {
+			switch strings.ToUpper(kv[0]) {
+			case "GUID":
+				e.guid = kv[1]
+			case "SID":
+				e.sid = kv[1]
+			}
+		}
+		v = strings.TrimPrefix(v[end+1:], ";")
+	}
+	e.dn = v
+	return e
+}
+
+// matches checks if given dn, GUID or SID string identifies the object.
+// sAMAccountName or userPrincipalName can't be matched and should be resolved to dn first.
+func (e extendedDN) matches(identity string) bool {
+	switch {
+	case isGUIDString(identity):
+		return strings.EqualFold(e.guid, identity)
+	case isSIDString(identity):
+		return strings.EqualFold(e.sid, identity)
+	}
+	return strings.EqualFold(e.dn, identity)
+}
+
+// matchObjectIdentities maps remote extended dn values back to the identities used in configuration so that
+// state doesn't change when object is renamed or moved. values which doesn't match any identity are returned as dn.
+// resolved contains dn of identities referenced by sAMAccountName or userPrincipalName keyed by lowercase identity.
+func matchObjectIdentities(identities []string, resolved map[string]string, extendedDNs []string) []string {
+	var matched []string
+	for _, v := range extendedDNs {
+		e := parseExtendedDN(v)
+		value := e.dn
+		for _, i := range identities {
+			id := i
+			if dn, ok := resolved[strings.ToLower(i)]; ok {
+				id = dn
+			}
+			if e.matches(id) {
+				value = i
+				break
+			}
+		}
+		matched = append(matched, value)
+	}
+	return matched
+}
+
+// diffDNs compares old and new dns ignoring case and returns dns only present in new as added and
+// dns only present in old as removed.
+func diffDNs(oldDNs, newDNs []string) (added []string, removed []string) {
+	oldSet := map[string]bool{}
+	newSet := map[string]bool{}
+	for _, dn := range oldDNs {
+		oldSet[strings.ToLower(dn)] = true
+	}
+	for _, dn := range newDNs {
+		newSet[strings.ToLower(dn)] = true
+	}
+	for _, dn := range newDNs {
+		if !oldSet[strings.ToLower(dn)] {
+			added = append(added, dn)
+			oldSet[strings.ToLower(dn)] = true
+		}
+	}
+	for _, dn := range oldDNs {
+		if !newSet[strings.ToLower(dn)] {
+			removed = append(removed, dn)
+			newSet[strings.ToLower(dn)] = true
+		}
+	}
+	return added, removed
+}
+
+// validateDNString validates dn of the parent OU or container of an object, it should be a valid DN
+// which is top dn or under top dn.
 func validateDNString(c *ADClient, ou string) error {
-	// validate OU Entry with to make sure its a full path
 	errStr := ""
-	if !strings.HasSuffix(strings.ToLower(ou), c.config.topDN) {
-		errStr += fmt.Sprintf(`full ou path should end with top dn %q : `, c.config.topDN)
-	}
-	if _, err := ldap.ParseDN(strings.ToLower(ou)); err != nil {
-		errStr += fmt.Sprintf("ou is not a valid DN err: %v", err)
+	pdn, err := ldap.ParseDN(strings.ToLower(ou))
+	if err != nil {
+		errStr += fmt.Sprintf("ou or container is not a valid DN err: %v", err)
+	} else if topDN, err := ldap.ParseDN(c.config.topDN); err != nil {
+		errStr += fmt.Sprintf("top dn %q is not a valid DN err: %v", c.config.topDN, err)
+	} else if !topDN.Equal(pdn) && !topDN.AncestorOf(pdn) {
+		errStr += fmt.Sprintf(`full ou or container path should end with top dn %q`, c.config.topDN)
 	}
 	if errStr != "" {
 		return fmt.Errorf("error: %s, got: %s", errStr, ou)
@@ -181,6 +426,34 @@ func decodeSID(sid []byte) (string, error) {
 	return sidStr, nil
 }
 
+// splitSID returns domain SID and relative identifier (RID) of given SID string.
+func splitSID(sid string) (string, string, error) {
+	if !isSIDString(sid) {
+		return "", "", fmt.Errorf("invalid SID string: %s", sid)
+	}
+	i := strings.LastIndex(sid, "-")
+	domainSID, rid := sid[:i], sid[i+1:]
+	if strings.Count(domainSID, "-") < 2 {
+		return "", "", fmt.Errorf("SID doesn't have relative identifier: %s", sid)
+	}
+	return domainSID, rid, nil
+}
+
+// validatePrimaryGroup makes sure value is a dn or SID of a group.
+func validatePrimaryGroup(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if isSIDString(v) {
+		return warns, errs
+	}
+	if _, err := ldap.ParseDN(v); err != nil || !isDNString(v) {
+		errs = append(errs, fmt.Errorf("%q should be valid DN or SID of a group, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
 func decodeGUID(b []byte) (string, error) {
 	if len(b) != 16 {
 		return "", fmt.Errorf("size of raw guid is not 16 guid-len:%v", len(b))
@@ -240,6 +513,74 @@ func unsetaccountDisabledFlag(userAccountControl string) (string, error) {
 	return strconv.FormatUint(uac&^accountDisabledFlag, 10), nil
 }
 
+func isUACFlagSet(userAccountControl string, flag uint64) (bool, error) {
+
+	uac, err := strconv.ParseUint(userAccountControl, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse userAccountControl to uint %s", userAccountControl)
+	}
+	return uac&flag != 0, nil
+}
+
+func setUACFlag(userAccountControl string, flag uint64) (string, error) {
+
+	uac, err := strconv.ParseUint(userAccountControl, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse userAccountControl to uint %s", userAccountControl)
+	}
+	return strconv.FormatUint(uac|flag, 10), nil
+}
+
+func unsetUACFlag(userAccountControl string, flag uint64) (string, error) {
+
+	uac, err := strconv.ParseUint(userAccountControl, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse userAccountControl to uint %s", userAccountControl)
+	}
+	return strconv.FormatUint(uac&^flag, 10), nil
+}
+
+// setUACFlagArguments sets or unsets userAccountControl flags based on typed boolean arguments.
+// if onlyChanged is true only arguments with changes are applied, otherwise only arguments set to true are applied.
+func setUACFlagArguments(d *schema.ResourceData, userAccountControl string, onlyChanged bool) (string, error) {
+	var err error
+	uac := userAccountControl
+	for arg, flag := range uacFlagArguments {
+		if onlyChanged && !d.HasChange(arg) {
+			continue
+		}
+		switch {
+		case d.Get(arg).(bool):
+			uac, err = setUACFlag(uac, flag)
+		case onlyChanged:
+			uac, err = unsetUACFlag(uac, flag)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return uac, nil
+}
+
+// addAttributesToModifyRequest adds replace changes of attributes which are changed in 'attributes' JSON argument to modReq.
+func addAttributesToModifyRequest(c *ADClient, d *schema.ResourceData, modReq *ldap.ModifyRequest) {
+	if !d.HasChange("attributes") {
+		return
+	}
+	oldAttrMap := map[string][]string{}
+	newAttrMap := map[string][]string{}
+
+	oldAttr, newAttr := d.GetChange("attributes")
+	_ = json.Unmarshal([]byte(oldAttr.(string)), &oldAttrMap)
+	_ = json.Unmarshal([]byte(newAttr.(string)), &newAttrMap)
+
+	replaced := getModifiedAttributes(oldAttrMap, newAttrMap)
+	for name, values := range replaced {
+		modReq.Replace(name, values)
+		c.logger.Debug("addAttributesToModifyRequest: Replacing 'attribute'", "name", name, "new_value", values)
+	}
+}
+
 // getModifiedAttributes will compare old and new attribute map and send difference in the as map added, replaced, deleted attributes
 func getModifiedAttributes(oldAttrMap, newAttrMap map[string][]string) map[string][]string {
 	replaced := map[string][]string{}
@@ -271,6 +612,19 @@ func getModifiedAttributes(oldAttrMap, newAttrMap map[string][]string) map[strin
 	return replaced
 }
 
+// chunkStrings splits values into slices of max given size.
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for from := 0; from < len(values); from += size {
+		to := from + size
+		if to > len(values) {
+			to = len(values)
+		}
+		chunks = append(chunks, values[from:to])
+	}
+	return chunks
+}
+
 func compareAttrValues(old, new []string) bool {
 	if len(old) != len(new) {
 		return false
@@ -322,3 +676,798 @@ func getGroupTypeScope(value string) (string, string, error) {
 
 	return "", "", fmt.Errorf("unable to get scope or value for given group type value: %v", value)
 }
+
+func fileTimeToTime(ft int64) time.Time {
+	d := ft - fileTimeUnixEpoch
+	return time.Unix(d/1e7, (d%1e7)*100).UTC()
+}
+
+func timeToFileTime(t time.Time) int64 {
+	return t.Unix()*1e7 + int64(t.Nanosecond())/100 + fileTimeUnixEpoch
+}
+
+// formatFileTime converts FILETIME attribute value to RFC3339 timestamp,
+// empty string is returned for 0 value and 'never' for max FILETIME value.
+func formatFileTime(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	ft, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse FILETIME value %s to int", v)
+	}
+	switch ft {
+	case 0:
+		return "", nil
+	case fileTimeNever:
+		return accountExpiresNever, nil
+	}
+	return fileTimeToTime(ft).Format(time.RFC3339), nil
+}
+
+// accountExpiresToString converts accountExpires attribute value to RFC3339 timestamp or 'never',
+// AD uses both 0 and max FILETIME value for accounts which never expire.
+func accountExpiresToString(v string) (string, error) {
+	t, err := formatFileTime(v)
+	if err != nil {
+		return "", err
+	}
+	if t == "" {
+		return accountExpiresNever, nil
+	}
+	return t, nil
+}
+
+// accountExpiresFromString converts RFC3339 timestamp or 'never' to accountExpires attribute value.
+func accountExpiresFromString(v string) (string, error) {
+	if strings.EqualFold(v, accountExpiresNever) {
+		return "0", nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse %s as RFC3339 timestamp err:%w", v, err)
+	}
+	return strconv.FormatInt(timeToFileTime(t), 10), nil
+}
+
+func validateAccountExpires(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, err := accountExpiresFromString(v); err != nil {
+		errs = append(errs, fmt.Errorf("%q should be RFC3339 timestamp or 'never', got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// accountExpiresDiffSuppressor suppresses diff of timestamps which represents same time in different time zones.
+func accountExpiresDiffSuppressor(k, old, new string, d *schema.ResourceData) bool {
+	if strings.EqualFold(old, new) {
+		return true
+	}
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+// intervalNever is value of interval attributes ie maxPwdAge or lockoutDuration which have no limit.
+const intervalNever int64 = math.MinInt64
+
+// durationNever represents intervalNever value in duration arguments.
+const durationNever = "never"
+
+// durationToInterval converts duration ie '720h' or 'never' to value of AD interval attributes,
+// intervals are stored as negative number of 100 nanoseconds.
+func durationToInterval(v string) (string, error) {
+	if strings.EqualFold(v, durationNever) {
+		return strconv.FormatInt(intervalNever, 10), nil
+	}
+	dur, err := time.ParseDuration(v)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse %s as duration err:%w", v, err)
+	}
+	if dur < 0 {
+		return "", fmt.Errorf("duration %s should not be negative", v)
+	}
+	return strconv.FormatInt(-int64(dur/100), 10), nil
+}
+
+// intervalToDuration converts value of AD interval attributes to duration, min int64 value is returned as 'never'.
+func intervalToDuration(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse interval value %s to int", v)
+	}
+	if i == intervalNever {
+		return durationNever, nil
+	}
+	if i < 0 {
+		i = -i
+	}
+	return time.Duration(i * 100).String(), nil
+}
+
+func validateDuration(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, err := durationToInterval(v); err != nil {
+		errs = append(errs, fmt.Errorf("%q should be duration ie '720h', '30m' or 'never', got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// durationDiffSuppressor suppresses diff of durations which are equal but formatted differently ie '24h' and '24h0m0s'.
+func durationDiffSuppressor(k, old, new string, d *schema.ResourceData) bool {
+	o, err := durationToInterval(old)
+	if err != nil {
+		return false
+	}
+	n, err := durationToInterval(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// setPwdPropertiesFlag sets or unsets flag of pwdProperties attribute value, other flags are preserved.
+func setPwdPropertiesFlag(pwdProperties string, flag uint64, set bool) (string, error) {
+	if pwdProperties == "" {
+		pwdProperties = "0"
+	}
+	p, err := strconv.ParseUint(pwdProperties, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse pwdProperties to uint %s", pwdProperties)
+	}
+	if set {
+		return strconv.FormatUint(p|flag, 10), nil
+	}
+	return strconv.FormatUint(p&^flag, 10), nil
+}
+
+// tlsServerName returns host name of LDAP URL which is used to verify server certificate.
+func tlsServerName(serverURL string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse LDAP URL %s err:%w", serverURL, err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("LDAP URL %s doesn't contain host name", serverURL)
+	}
+	return u.Hostname(), nil
+}
+
+// passwordVersionDiffSuppressor suppresses diff of write-only password, password is only reset
+// when 'password_version' changes and it's never persisted in state.
+func passwordVersionDiffSuppressor(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("password_version").(int) == 0 {
+		return false
+	}
+	return !d.HasChange("password_version")
+}
+
+// isPasswordVersionDrifted checks if password was set outside of terraform since the password of
+// current 'password_version' was set. empty tracked value means password is not tracked yet.
+func isPasswordVersionDrifted(tracked, lastSet string) bool {
+	return tracked != "" && tracked != lastSet
+}
+
+// isLockedOut checks if account is locked out at given time based on lockoutTime attribute value and
+// lockoutDuration of the domain. lockoutDuration is negative value in 100 nanoseconds intervals,
+// 0 or min int64 value means account is locked out until administrator unlocks it.
+func isLockedOut(lockoutTime string, lockoutDuration int64, now time.Time) (bool, error) {
+	if lockoutTime == "" {
+		return false, nil
+	}
+	lt, err := strconv.ParseInt(lockoutTime, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse lockoutTime value %s to int", lockoutTime)
+	}
+	if lt == 0 {
+		return false, nil
+	}
+	if lockoutDuration == 0 || lockoutDuration == math.MinInt64 {
+		return true, nil
+	}
+	if lockoutDuration < 0 {
+		lockoutDuration = -lockoutDuration
+	}
+	return fileTimeToTime(lt).Add(time.Duration(lockoutDuration) * 100).After(now), nil
+}
+
+// isAttributeInJSON checks if attribute is defined in attributes JSON, attribute names are case insensitive.
+func isAttributeInJSON(attributes, name string) bool {
+	attrMap := map[string][]string{}
+	_ = json.Unmarshal([]byte(attributes), &attrMap)
+	for n := range attrMap {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// countryAttributeValues returns values of c, co and countryCode attributes for given ISO 3166-1 alpha-2 code,
+// empty code clears c and co and sets countryCode to 0.
+func countryAttributeValues(code string) (map[string][]string, error) {
+	if code == "" {
+		return map[string][]string{"c": {}, "co": {}, "countryCode": {"0"}}, nil
+	}
+	ct, ok := countries[strings.ToUpper(code)]
+	if !ok {
+		return nil, fmt.Errorf("unknown ISO 3166-1 alpha-2 country code %s", code)
+	}
+	return map[string][]string{
+		"c":           {strings.ToUpper(code)},
+		"co":          {ct.name},
+		"countryCode": {strconv.Itoa(ct.code)},
+	}, nil
+}
+
+func validateCountryCode(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, ok := countries[strings.ToUpper(v)]; !ok {
+		errs = append(errs, fmt.Errorf("%q should be ISO 3166-1 alpha-2 country code, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// validateDNOrGUID makes sure value is a dn or GUID of an object.
+func validateDNOrGUID(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if isGUIDString(v) {
+		return warns, errs
+	}
+	if _, err := ldap.ParseDN(v); err != nil || !isDNString(v) {
+		errs = append(errs, fmt.Errorf("%q should be valid DN or GUID, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// validateDNOrSID makes sure value is a dn or SID of an object.
+func validateDNOrSID(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if isSIDString(v) {
+		return warns, errs
+	}
+	if _, err := ldap.ParseDN(v); err != nil || !isDNString(v) {
+		errs = append(errs, fmt.Errorf("%q should be valid DN or SID, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// validateDN makes sure value is a dn of an object.
+func validateDN(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, err := ldap.ParseDN(v); err != nil || !isDNString(v) {
+		errs = append(errs, fmt.Errorf("%q should be valid DN, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// validateACERight makes sure value is a known access right name or hexadecimal access mask.
+func validateACERight(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, err := rightsToMask([]string{v}); err != nil {
+		errs = append(errs, fmt.Errorf("%q should be access right name or hexadecimal access mask ie '0x100', got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// validateObjectType makes sure value is a GUID or known name of object type.
+func validateObjectType(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, err := resolveObjectType(v); err != nil {
+		errs = append(errs, fmt.Errorf("%q should be GUID or known name of class, attribute, property set or extended right, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+func validateUNCPath(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if !uncPathRegexp.MatchString(v) {
+		errs = append(errs, fmt.Errorf(`%q should be UNC path ie \\server\share\folder, got value:%s`, key, v))
+	}
+	return warns, errs
+}
+
+// validateScriptPath makes sure value is a UNC path or path relative to NETLOGON share.
+func validateScriptPath(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if strings.HasPrefix(v, `\\`) {
+		return validateUNCPath(val, key)
+	}
+	if strings.ContainsAny(v, `:*?"<>|`) || strings.HasPrefix(v, `\`) || strings.HasPrefix(v, "/") {
+		errs = append(errs, fmt.Errorf("%q should be UNC path or path relative to NETLOGON share, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// validateWorkstationName makes sure value is a valid NetBIOS computer name which can be used in userWorkstations.
+func validateWorkstationName(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if v == "" || len(v) > 15 || strings.ContainsAny(v, `,\/:*?"<>| `) {
+		errs = append(errs, fmt.Errorf("%q entry should be NetBIOS computer name with 15 or fewer characters, got value:%s", key, v))
+	}
+	return warns, errs
+}
+
+// weekdays are names of days in the order used by logonHours attribute
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// logonHoursLength is length of logonHours attribute, 1 bit for each hour of the week
+const logonHoursLength = 21
+
+var utcOffsetRegexp = regexp.MustCompile(`^[+-]([01][0-9]|2[0-3]):00$`)
+
+// logonHoursRange represents allowed logon hours [start, end) of a day in local time,
+// day is index of weekdays.
+type logonHoursRange struct {
+	day   int
+	start int
+	end   int
+}
+
+// logonHoursOffset returns offset from UTC in hours for given time zone, time zone can be IANA time zone name
+// or fixed offset ie '+02:00'. since logonHours doesn't support daylight saving time standard offset of the
+// time zone is used.
+func logonHoursOffset(tz string) (int, error) {
+	if utcOffsetRegexp.MatchString(tz) {
+		h, _ := strconv.Atoi(tz[1:3])
+		if tz[0] == '-' {
+			h = -h
+		}
+		return h, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return 0, fmt.Errorf("unable to load time zone %s err:%w", tz, err)
+	}
+	// daylight saving time only adds to the offset so smaller offset of January and July is standard offset
+	_, jan := time.Date(2021, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, jul := time.Date(2021, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	offset := jan
+	if jul < offset {
+		offset = jul
+	}
+	if offset%3600 != 0 {
+		return 0, fmt.Errorf("time zone %s offset is not in whole hours, logonHours only supports whole hours", tz)
+	}
+	return offset / 3600, nil
+}
+
+// encodeLogonHours converts local time ranges to logonHours bitmap stored in UTC,
+// bit 0 of first byte is Sunday 00:00-01:00 UTC.
+func encodeLogonHours(ranges []logonHoursRange, offset int) []byte {
+	b := make([]byte, logonHoursLength)
+	for _, r := range ranges {
+		for h := r.start; h < r.end; h++ {
+			wh := ((r.day*24+h-offset)%168 + 168) % 168
+			b[wh/8] |= 1 << uint(wh%8)
+		}
+	}
+	return b
+}
+
+// decodeLogonHours converts logonHours bitmap to contiguous ranges of each day in local time,
+// ranges are sorted by day and start hour.
+func decodeLogonHours(b []byte, offset int) ([]logonHoursRange, error) {
+	if len(b) != logonHoursLength {
+		return nil, fmt.Errorf("logonHours should be %d bytes, got:%d", logonHoursLength, len(b))
+	}
+	var local [168]bool
+	for wh := 0; wh < 168; wh++ {
+		if b[wh/8]&(1<<uint(wh%8)) != 0 {
+			local[((wh+offset)%168+168)%168] = true
+		}
+	}
+
+	var ranges []logonHoursRange
+	for day := 0; day < 7; day++ {
+		start := -1
+		for h := 0; h <= 24; h++ {
+			allowed := h < 24 && local[day*24+h]
+			switch {
+			case allowed && start == -1:
+				start = h
+			case !allowed && start != -1:
+				ranges = append(ranges, logonHoursRange{day: day, start: start, end: h})
+				start = -1
+			}
+		}
+	}
+	return ranges, nil
+}
+
+// isAllLogonHours checks if logonHours allows logon at all hours, which is same as not having the attribute.
+func isAllLogonHours(b []byte) bool {
+	for _, v := range b {
+		if v != 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// validateLogonHoursRanges makes sure ranges of the same day don't overlap or touch each other,
+// otherwise ranges read from AD would be merged and differ from configuration. schedule allowing all hours
+// of the week is rejected since it's read from AD the same as no logon_hours block.
+func validateLogonHoursRanges(ranges []logonHoursRange) error {
+	hours := 0
+	for i, a := range ranges {
+		hours += a.end - a.start
+		if a.start >= a.end {
+			return fmt.Errorf("logon hours range of %s start:%d should be before end:%d", weekdays[a.day], a.start, a.end)
+		}
+		for _, b := range ranges[i+1:] {
+			if a.day == b.day && a.start <= b.end && b.start <= a.end {
+				return fmt.Errorf("logon hours ranges of %s %d-%d and %d-%d overlap or are adjacent, merge them into one range",
+					weekdays[a.day], a.start, a.end, b.start, b.end)
+			}
+		}
+	}
+	if hours == 7*24 {
+		return fmt.Errorf("logon hours schedule allows all hours of the week, remove logon_hours block instead")
+	}
+	return nil
+}
+
+func weekdayIndex(day string) int {
+	for i, d := range weekdays {
+		if strings.EqualFold(d, day) {
+			return i
+		}
+	}
+	return -1
+}
+
+func validateLogonHoursTimezone(val interface{}, key string) ([]string, []error) {
+	var errs []error
+	var warns []string
+
+	v := val.(string)
+	if _, err := logonHoursOffset(v); err != nil {
+		errs = append(errs, fmt.Errorf("%q should be IANA time zone name or UTC offset in whole hours ie '+02:00', got value:%s err:%v", key, v, err))
+	}
+	return warns, errs
+}
+
+// expandLogonHours converts logon_hours block to logonHours value, nil is returned if block is not set.
+func expandLogonHours(v []interface{}) ([]byte, error) {
+	if len(v) == 0 || v[0] == nil {
+		return nil, nil
+	}
+	m := v[0].(map[string]interface{})
+	offset, err := logonHoursOffset(m["timezone"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges []logonHoursRange
+	for _, s := range m["schedule"].(*schema.Set).List() {
+		sm := s.(map[string]interface{})
+		day := weekdayIndex(sm["day"].(string))
+		if day == -1 {
+			return nil, fmt.Errorf("unknown day of week %s", sm["day"].(string))
+		}
+		ranges = append(ranges, logonHoursRange{day: day, start: sm["start"].(int), end: sm["end"].(int)})
+	}
+	if err := validateLogonHoursRanges(ranges); err != nil {
+		return nil, err
+	}
+	return encodeLogonHours(ranges, offset), nil
+}
+
+// flattenLogonHours converts logonHours value to logon_hours block in given time zone,
+// empty value or value allowing all hours is returned as no block.
+func flattenLogonHours(b []byte, tz string) ([]interface{}, error) {
+	if len(b) == 0 || isAllLogonHours(b) {
+		return []interface{}{}, nil
+	}
+	offset, err := logonHoursOffset(tz)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := decodeLogonHours(b, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := make([]interface{}, len(ranges))
+	for i, r := range ranges {
+		schedule[i] = map[string]interface{}{"day": weekdays[r.day], "start": r.start, "end": r.end}
+	}
+	return []interface{}{map[string]interface{}{"timezone": tz, "schedule": schedule}}, nil
+}
+
+// character sets used by password generation, ambiguous characters are not excluded since password is not typed by users
+const (
+	passwordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigitChars   = "0123456789"
+	passwordSpecialChars = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+)
+
+// passwordGenerationLength is default length of generated passwords, policy min length is used if it's longer
+const passwordGenerationLength = 24
+
+// generatePassword generates random password of given length with at least one character of each character set,
+// password is regenerated if it contains any of account names as required by complexity rules.
+func generatePassword(length int, complexity bool, sam, displayName string) (string, error) {
+	sets := []string{passwordLowerChars, passwordUpperChars, passwordDigitChars, passwordSpecialChars}
+	all := strings.Join(sets, "")
+	if length < len(sets) {
+		length = len(sets)
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		pwd := make([]byte, length)
+		for i := range pwd {
+			charset := all
+			if complexity && i < len(sets) {
+				charset = sets[i]
+			}
+			c, err := randomChar(charset)
+			if err != nil {
+				return "", err
+			}
+			pwd[i] = c
+		}
+		// shuffle so that required characters are not always at the beginning
+		for i := len(pwd) - 1; i > 0; i-- {
+			j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+			if err != nil {
+				return "", err
+			}
+			pwd[i], pwd[j.Int64()] = pwd[j.Int64()], pwd[i]
+		}
+		if !containsAccountName(string(pwd), sam, displayName) {
+			return string(pwd), nil
+		}
+	}
+	return "", fmt.Errorf("unable to generate password which doesn't contain account name")
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate random number err:%w", err)
+	}
+	return charset[n.Int64()], nil
+}
+
+// encryptionTypes maps supported_encryption_types values to msDS-SupportedEncryptionTypes bits
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-kile/6cfc7b50-11ed-4b4d-846d-6f08f0812919
+var encryptionTypes = map[string]uint64{
+	"DES_CBC_CRC": 0x1,
+	"DES_CBC_MD5": 0x2,
+	"RC4":         0x4,
+	"AES128":      0x8,
+	"AES256":      0x10,
+}
+
+// encryptionTypesMask contains all bits of encryptionTypes, other bits of msDS-SupportedEncryptionTypes are
+// not encryption types (e.g. FAST or claims support) and are kept as they are.
+const encryptionTypesMask uint64 = 0x1f
+
+// encodeEncryptionTypes returns msDS-SupportedEncryptionTypes value of given encryption types,
+// bits of current value which are not encryption types are preserved.
+func encodeEncryptionTypes(types []string, current string) (string, error) {
+	var v uint64
+	if current != "" {
+		c, err := strconv.ParseUint(current, 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse msDS-SupportedEncryptionTypes value %s to int", current)
+		}
+		v = c &^ encryptionTypesMask
+	}
+	for _, t := range types {
+		bit, ok := encryptionTypes[t]
+		if !ok {
+			return "", fmt.Errorf("unknown encryption type %s", t)
+		}
+		v |= bit
+	}
+	return strconv.FormatUint(v, 10), nil
+}
+
+// decodeEncryptionTypes returns sorted encryption types of msDS-SupportedEncryptionTypes value.
+func decodeEncryptionTypes(v string) ([]string, error) {
+	types := []string{}
+	if v == "" {
+		return types, nil
+	}
+	bits, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse msDS-SupportedEncryptionTypes value %s to int", v)
+	}
+	for t, bit := range encryptionTypes {
+		if bits&bit != 0 {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// encryptionTypeNames returns sorted names of supported encryption types.
+func encryptionTypeNames() []string {
+	var names []string
+	for t := range encryptionTypes {
+		names = append(names, t)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateMSASAMAccountName validates sAMAccountName of managed service account, the name must end with
+// dollar sign and it's limited to 15 characters without the dollar sign.
+func validateMSASAMAccountName(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !strings.HasSuffix(v, "$") {
+		errs = append(errs, fmt.Errorf("sAMAccountName attribute of a managed service account should have trailing dollar sign ('$'), got: %s", v))
+	}
+	if len(strings.TrimSuffix(v, "$")) > 15 {
+		errs = append(errs, fmt.Errorf("sAMAccountName attribute of a managed service account is limited to MAX 15 characters without trailing dollar sign, got value:%s count:%d", v, len(strings.TrimSuffix(v, "$"))))
+	}
+	return
+}
+
+// flattenPrincipals returns principals for the state, configured identity is kept if its SID is
+// in the given SIDs, SIDs which doesn't match any configured identity are returned as they are.
+func flattenPrincipals(sids []string, configured map[string]string) []string {
+	matched := map[string]bool{}
+	var principals []string
+	for identity, sid := range configured {
+		for _, s := range sids {
+			if strings.EqualFold(s, sid) {
+				principals = append(principals, identity)
+				matched[strings.ToUpper(s)] = true
+				break
+			}
+		}
+	}
+	for _, s := range sids {
+		if !matched[strings.ToUpper(s)] {
+			principals = append(principals, s)
+		}
+	}
+	sort.Strings(principals)
+	return principals
+}
+
+// password_change_mode values, reset replaces unicodePwd and change deletes old and adds new unicodePwd value.
+const (
+	passwordChangeModeReset  = "reset"
+	passwordChangeModeChange = "change"
+)
+
+// passwordComplexityCategories is number of character categories required by password complexity rules.
+const passwordComplexityCategories = 3
+
+// validatePassword checks password against length and complexity requirements of the password policy,
+// complexity requires characters of three out of five categories and password must not contain account name.
+// https://docs.microsoft.com/en-us/windows/security/threat-protection/security-policy-settings/password-must-meet-complexity-requirements
+func validatePassword(password string, policy *passwordPolicy, sam, displayName string) error {
+	if length := utf8.RuneCountInString(password); length < policy.minLength {
+		return fmt.Errorf("password is %d characters long, password policy %s requires at least %d characters", length, policy.dn, policy.minLength)
+	}
+	if !policy.complexity {
+		return nil
+	}
+	var upper, lower, digit, special, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsLetter(r):
+			other = true
+		default:
+			special = true
+		}
+	}
+	categories := 0
+	for _, ok := range []bool{upper, lower, digit, special, other} {
+		if ok {
+			categories++
+		}
+	}
+	if categories < passwordComplexityCategories {
+		return fmt.Errorf("password contains characters of %d categories, password policy %s requires at least %d of uppercase, lowercase, digits, special and other unicode characters", categories, policy.dn, passwordComplexityCategories)
+	}
+	if containsAccountName(password, sam, displayName) {
+		return fmt.Errorf("password contains sAMAccountName or part of display name, which is not allowed by password policy %s", policy.dn)
+	}
+	return nil
+}
+
+// containsAccountName checks password against account name rules of password complexity,
+// password should not contain sAMAccountName or any token of display name which are 3 or more characters long.
+// https://docs.microsoft.com/en-us/windows/security/threat-protection/security-policy-settings/password-must-meet-complexity-requirements
+func containsAccountName(password, sam, displayName string) bool {
+	pwd := strings.ToLower(password)
+	if len(sam) >= 3 && strings.Contains(pwd, strings.ToLower(sam)) {
+		return true
+	}
+	tokens := strings.FieldsFunc(displayName, func(r rune) bool {
+		return strings.ContainsRune(",.-_# \t", r)
+	})
+	for _, t := range tokens {
+		if len(t) >= 3 && strings.Contains(pwd, strings.ToLower(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenObjectClasses returns object classes for the state, configured classes are kept if object
+// has them, if none is configured ie on import the most specific class of the object is returned.
+func flattenObjectClasses(configured, remote []string) []string {
+	var classes []string
+	for _, c := range configured {
+		for _, r := range remote {
+			if strings.EqualFold(c, r) {
+				classes = append(classes, c)
+				break
+			}
+		}
+	}
+	if len(configured) == 0 && len(remote) > 0 {
+		classes = []string{remote[len(remote)-1]}
+	}
+	return classes
+}
+
+// getRDNAttribute returns the attribute type of the relative distinguished name of dn in lower case.
+func getRDNAttribute(dn string) (string, error) {
+	pdn, err := ldap.ParseDN(dn)
+	if err != nil {
+		return "", err
+	}
+	if len(pdn.RDNs) == 0 || len(pdn.RDNs[0].Attributes) == 0 {
+		return "", fmt.Errorf("dn %q has no relative distinguished name", dn)
+	}
+	return strings.ToLower(pdn.RDNs[0].Attributes[0].Type), nil
+}
@@ -0,0 +1,261 @@
+package activedirectory
+
+// country represents ISO 3166-1 country, name is stored in 'co' attribute and code in 'countryCode' attribute
+// while 2 letter code is stored in 'c' attribute of the AD object.
+type country struct {
+	name string
+	code int
+}
+
+// countries contains ISO 3166-1 countries by alpha-2 code
+var countries = map[string]country{
+	"AD": {"Andorra", 20},
+	"AE": {"United Arab Emirates", 784},
+	"AF": {"Afghanistan", 4},
+	"AG": {"Antigua and Barbuda", 28},
+	"AI": {"Anguilla", 660},
+	"AL": {"Albania", 8},
+	"AM": {"Armenia", 51},
+	"AO": {"Angola", 24},
+	"AQ": {"Antarctica", 10},
+	"AR": {"Argentina", 32},
+	"AS": {"American Samoa", 16},
+	"AT": {"Austria", 40},
+	"AU": {"Australia", 36},
+	"AW": {"Aruba", 533},
+	"AX": {"Åland Islands", 248},
+	"AZ": {"Azerbaijan", 31},
+	"BA": {"Bosnia and Herzegovina", 70},
+	"BB": {"Barbados", 52},
+	"BD": {"Bangladesh", 50},
+	"BE": {"Belgium", 56},
+	"BF": {"Burkina Faso", 854},
+	"BG": {"Bulgaria", 100},
+	"BH": {"Bahrain", 48},
+	"BI": {"Burundi", 108},
+	"BJ": {"Benin", 204},
+	"BL": {"Saint Barthélemy", 652},
+	"BM": {"Bermuda", 60},
+	"BN": {"Brunei Darussalam", 96},
+	"BO": {"Bolivia, Plurinational State of", 68},
+	"BQ": {"Bonaire, Sint Eustatius and Saba", 535},
+	"BR": {"Brazil", 76},
+	"BS": {"Bahamas", 44},
+	"BT": {"Bhutan", 64},
+	"BV": {"Bouvet Island", 74},
+	"BW": {"Botswana", 72},
+	"BY": {"Belarus", 112},
+	"BZ": {"Belize", 84},
+	"CA": {"Canada", 124},
+	"CC": {"Cocos (Keeling) Islands", 166},
+	"CD": {"Congo, The Democratic Republic of the", 180},
+	"CF": {"Central African Republic", 140},
+	"CG": {"Congo", 178},
+	"CH": {"Switzerland", 756},
+	"CI": {"Côte d'Ivoire", 384},
+	"CK": {"Cook Islands", 184},
+	"CL": {"Chile", 152},
+	"CM": {"Cameroon", 120},
+	"CN": {"China", 156},
+	"CO": {"Colombia", 170},
+	"CR": {"Costa Rica", 188},
+	"CU": {"Cuba", 192},
+	"CV": {"Cabo Verde", 132},
+	"CW": {"Curaçao", 531},
+	"CX": {"Christmas Island", 162},
+	"CY": {"Cyprus", 196},
+	"CZ": {"Czechia", 203},
+	"DE": {"Germany", 276},
+	"DJ": {"Djibouti", 262},
+	"DK": {"Denmark", 208},
+	"DM": {"Dominica", 212},
+	"DO": {"Dominican Republic", 214},
+	"DZ": {"Algeria", 12},
+	"EC": {"Ecuador", 218},
+	"EE": {"Estonia", 233},
+	"EG": {"Egypt", 818},
+	"EH": {"Western Sahara", 732},
+	"ER": {"Eritrea", 232},
+	"ES": {"Spain", 724},
+	"ET": {"Ethiopia", 231},
+	"FI": {"Finland", 246},
+	"FJ": {"Fiji", 242},
+	"FK": {"Falkland Islands (Malvinas)", 238},
+	"FM": {"Micronesia, Federated States of", 583},
+	"FO": {"Faroe Islands", 234},
+	"FR": {"France", 250},
+	"GA": {"Gabon", 266},
+	"GB": {"United Kingdom", 826},
+	"GD": {"Grenada", 308},
+	"GE": {"Georgia", 268},
+	"GF": {"French Guiana", 254},
+	"GG": {"Guernsey", 831},
+	"GH": {"Ghana", 288},
+	"GI": {"Gibraltar", 292},
+	"GL": {"Greenland", 304},
+	"GM": {"Gambia", 270},
+	"GN": {"Guinea", 324},
+	"GP": {"Guadeloupe", 312},
+	"GQ": {"Equatorial Guinea", 226},
+	"GR": {"Greece", 300},
+	"GS": {"South Georgia and the South Sandwich Islands", 239},
+	"GT": {"Guatemala", 320},
+	"GU": {"Guam", 316},
+	"GW": {"Guinea-Bissau", 624},
+	"GY": {"Guyana", 328},
+	"HK": {"Hong Kong", 344},
+	"HM": {"Heard Island and McDonald Islands", 334},
+	"HN": {"Honduras", 340},
+	"HR": {"Croatia", 191},
+	"HT": {"Haiti", 332},
+	"HU": {"Hungary", 348},
+	"ID": {"Indonesia", 360},
+	"IE": {"Ireland", 372},
+	"IL": {"Israel", 376},
+	"IM": {"Isle of Man", 833},
+	"IN": {"India", 356},
+	"IO": {"British Indian Ocean Territory", 86},
+	"IQ": {"Iraq", 368},
+	"IR": {"Iran, Islamic Republic of", 364},
+	"IS": {"Iceland", 352},
+	"IT": {"Italy", 380},
+	"JE": {"Jersey", 832},
+	"JM": {"Jamaica", 388},
+	"JO": {"Jordan", 400},
+	"JP": {"Japan", 392},
+	"KE": {"Kenya", 404},
+	"KG": {"Kyrgyzstan", 417},
+	"KH": {"Cambodia", 116},
+	"KI": {"Kiribati", 296},
+	"KM": {"Comoros", 174},
+	"KN": {"Saint Kitts and Nevis", 659},
+	"KP": {"Korea, Democratic People's Republic of", 408},
+	"KR": {"Korea, Republic of", 410},
+	"KW": {"Kuwait", 414},
+	"KY": {"Cayman Islands", 136},
+	"KZ": {"Kazakhstan", 398},
+	"LA": {"Lao People's Democratic Republic", 418},
+	"LB": {"Lebanon", 422},
+	"LC": {"Saint Lucia", 662},
+	"LI": {"Liechtenstein", 438},
+	"LK": {"Sri Lanka", 144},
+	"LR": {"Liberia", 430},
+	"LS": {"Lesotho", 426},
+	"LT": {"Lithuania", 440},
+	"LU": {"Luxembourg", 442},
+	"LV": {"Latvia", 428},
+	"LY": {"Libya", 434},
+	"MA": {"Morocco", 504},
+	"MC": {"Monaco", 492},
+	"MD": {"Moldova, Republic of", 498},
+	"ME": {"Montenegro", 499},
+	"MF": {"Saint Martin (French part)", 663},
+	"MG": {"Madagascar", 450},
+	"MH": {"Marshall Islands", 584},
+	"MK": {"North Macedonia", 807},
+	"ML": {"Mali", 466},
+	"MM": {"Myanmar", 104},
+	"MN": {"Mongolia", 496},
+	"MO": {"Macao", 446},
+	"MP": {"Northern Mariana Islands", 580},
+	"MQ": {"Martinique", 474},
+	"MR": {"Mauritania", 478},
+	"MS": {"Montserrat", 500},
+	"MT": {"Malta", 470},
+	"MU": {"Mauritius", 480},
+	"MV": {"Maldives", 462},
+	"MW": {"Malawi", 454},
+	"MX": {"Mexico", 484},
+	"MY": {"Malaysia", 458},
+	"MZ": {"Mozambique", 508},
+	"NA": {"Namibia", 516},
+	"NC": {"New Caledonia", 540},
+	"NE": {"Niger", 562},
+	"NF": {"Norfolk Island", 574},
+	"NG": {"Nigeria", 566},
+	"NI": {"Nicaragua", 558},
+	"NL": {"Netherlands", 528},
+	"NO": {"Norway", 578},
+	"NP": {"Nepal", 524},
+	"NR": {"Nauru", 520},
+	"NU": {"Niue", 570},
+	"NZ": {"New Zealand", 554},
+	"OM": {"Oman", 512},
+	"PA": {"Panama", 591},
+	"PE": {"Peru", 604},
+	"PF": {"French Polynesia", 258},
+	"PG": {"Papua New Guinea", 598},
+	"PH": {"Philippines", 608},
+	"PK": {"Pakistan", 586},
+	"PL": {"Poland", 616},
+	"PM": {"Saint Pierre and Miquelon", 666},
+	"PN": {"Pitcairn", 612},
+	"PR": {"Puerto Rico", 630},
+	"PS": {"Palestine, State of", 275},
+	"PT": {"Portugal", 620},
+	"PW": {"Palau", 585},
+	"PY": {"Paraguay", 600},
+	"QA": {"Qatar", 634},
+	"RE": {"Réunion", 638},
+	"RO": {"Romania", 642},
+	"RS": {"Serbia", 688},
+	"RU": {"Russian Federation", 643},
+	"RW": {"Rwanda", 646},
+	"SA": {"Saudi Arabia", 682},
+	"SB": {"Solomon Islands", 90},
+	"SC": {"Seychelles", 690},
+	"SD": {"Sudan", 729},
+	"SE": {"Sweden", 752},
+	"SG": {"Singapore", 702},
+	"SH": {"Saint Helena, Ascension and Tristan da Cunha", 654},
+	"SI": {"Slovenia", 705},
+	"SJ": {"Svalbard and Jan Mayen", 744},
+	"SK": {"Slovakia", 703},
+	"SL": {"Sierra Leone", 694},
+	"SM": {"San Marino", 674},
+	"SN": {"Senegal", 686},
+	"SO": {"Somalia", 706},
+	"SR": {"Suriname", 740},
+	"SS": {"South Sudan", 728},
+	"ST": {"Sao Tome and Principe", 678},
+	"SV": {"El Salvador", 222},
+	"SX": {"Sint Maarten (Dutch part)", 534},
+	"SY": {"Syrian Arab Republic", 760},
+	"SZ": {"Eswatini", 748},
+	"TC": {"Turks and Caicos Islands", 796},
+	"TD": {"Chad", 148},
+	"TF": {"French Southern Territories", 260},
+	"TG": {"Togo", 768},
+	"TH": {"Thailand", 764},
+	"TJ": {"Tajikistan", 762},
+	"TK": {"Tokelau", 772},
+	"TL": {"Timor-Leste", 626},
+	"TM": {"Turkmenistan", 795},
+	"TN": {"Tunisia", 788},
+	"TO": {"Tonga", 776},
+	"TR": {"Türkiye", 792},
+	"TT": {"Trinidad and Tobago", 780},
+	"TV": {"Tuvalu", 798},
+	"TW": {"Taiwan, Province of China", 158},
+	"TZ": {"Tanzania, United Republic of", 834},
+	"UA": {"Ukraine", 804},
+	"UG": {"Uganda", 800},
+	"UM": {"United States Minor Outlying Islands", 581},
+	"US": {"United States", 840},
+	"UY": {"Uruguay", 858},
+	"UZ": {"Uzbekistan", 860},
+	"VA": {"Holy See (Vatican City State)", 336},
+	"VC": {"Saint Vincent and the Grenadines", 670},
+	"VE": {"Venezuela, Bolivarian Republic of", 862},
+	"VG": {"Virgin Islands, British", 92},
+	"VI": {"Virgin Islands, U.S.", 850},
+	"VN": {"Viet Nam", 704},
+	"VU": {"Vanuatu", 548},
+	"WF": {"Wallis and Futuna", 876},
+	"WS": {"Samoa", 882},
+	"YE": {"Yemen", 887},
+	"YT": {"Mayotte", 175},
+	"ZA": {"South Africa", 710},
+	"ZM": {"Zambia", 894},
+	"ZW": {"Zimbabwe", 716},
+}
@@ -3,6 +3,7 @@ package activedirectory
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/go-ldap/ldap/v3"
@@ -77,6 +78,16 @@ func updateObjectSchema(resourceSchema map[string]*schema.Schema, e *ldap.Entry,
 			if err := d.Set("enabled", status); err != nil {
 				return fmt.Errorf("updateObjectSchema: unable to update 'enabled' argument value:%v err:%w", status, err)
 			}
+		case "password_never_expires", "password_not_required", "smartcard_required", "trusted_for_delegation",
+			"trusted_to_auth_for_delegation", "not_delegated", "use_des_key_only", "dont_require_preauth":
+			ruac := e.GetAttributeValue("userAccountControl")
+			set, err := isUACFlagSet(ruac, uacFlagArguments[s])
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable parse userAccountControl to get '%s' argument value:%v err:%w", s, ruac, err)
+			}
+			if err := d.Set(s, set); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update '%s' argument value:%v err:%w", s, set, err)
+			}
 		case "attributes":
 			currentAttr := map[string][]string{}
 			newAttr := map[string][]string{}
@@ -110,6 +121,114 @@ func updateObjectSchema(resourceSchema map[string]*schema.Schema, e *ldap.Entry,
 			if err := d.Set("user_principal_name", rv); err != nil {
 				return fmt.Errorf("updateObjectSchema: unable to update 'userPrincipalName' argument value:%v err:%w", rv, err)
 			}
+		case "mail", "display_name", "initials", "title", "department", "company", "office", "telephone_number",
+			"mobile", "employee_id", "employee_type", "street", "city", "state", "postal_code", "home_page",
+			"home_directory", "home_drive", "profile_path", "script_path":
+			// attribute set in attributes JSON is not managed by typed argument, attribute is read only once
+			// argument is configured or tracked in state so values set outside of terraform are kept
+			attr := userStringAttributes[s]
+			if d.Get(s).(string) == "" || isAttributeInJSON(d.Get("attributes").(string), attr) {
+				continue
+			}
+			rv := e.GetAttributeValue(attr)
+			if err := d.Set(s, rv); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update '%s' argument value:%v err:%w", s, rv, err)
+			}
+		case "user_workstations":
+			// same as typed string attributes workstations are read only once configured or tracked in state
+			if d.Get("user_workstations").(*schema.Set).Len() == 0 || isAttributeInJSON(d.Get("attributes").(string), "userWorkstations") {
+				continue
+			}
+			var ws []string
+			if rv := e.GetAttributeValue("userWorkstations"); rv != "" {
+				ws = strings.Split(rv, ",")
+			}
+			if err := d.Set("user_workstations", schema.NewSet(lowercaseHashString, stringListToInterfaces(ws))); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'user_workstations' argument value:%v err:%w", ws, err)
+			}
+		case "logon_hours":
+			tz := "UTC"
+			if v, ok := d.Get("logon_hours.0.timezone").(string); ok && v != "" {
+				tz = v
+			}
+			lh, err := flattenLogonHours(e.GetRawAttributeValue("logonHours"), tz)
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to convert 'logonHours' value err:%w", err)
+			}
+			if err := d.Set("logon_hours", lh); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'logon_hours' argument value:%v err:%w", lh, err)
+			}
+		case "country":
+			if d.Get("country").(string) == "" || isAttributeInJSON(d.Get("attributes").(string), "c") {
+				continue
+			}
+			rv := e.GetAttributeValue("c")
+			if err := d.Set("country", rv); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'country' argument value:%v err:%w", rv, err)
+			}
+		case "account_expires":
+			rv, err := accountExpiresToString(e.GetAttributeValue("accountExpires"))
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to convert 'accountExpires' value err:%w", err)
+			}
+			if err := d.Set("account_expires", rv); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'account_expires' argument value:%v err:%w", rv, err)
+			}
+		case "change_password_at_next_logon":
+			// pwdLastSet is updated once user changes the password, so only pending change is reported
+			if e.GetAttributeValue("pwdLastSet") == "0" {
+				if err := d.Set("change_password_at_next_logon", true); err != nil {
+					return fmt.Errorf("updateObjectSchema: unable to update 'change_password_at_next_logon' argument value:%v err:%w", true, err)
+				}
+			}
+		case "password_last_set":
+			rv, err := formatFileTime(e.GetAttributeValue("pwdLastSet"))
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to convert 'pwdLastSet' value err:%w", err)
+			}
+			if err := d.Set("password_last_set", rv); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'password_last_set' argument value:%v err:%w", rv, err)
+			}
+		case "last_logon":
+			rv, err := formatFileTime(e.GetAttributeValue("lastLogonTimestamp"))
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to convert 'lastLogonTimestamp' value err:%w", err)
+			}
+			if err := d.Set("last_logon", rv); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'last_logon' argument value:%v err:%w", rv, err)
+			}
+
+		// service account attributes
+		case "dns_host_name":
+			rv := e.GetAttributeValue("dNSHostName")
+			if err := d.Set("dns_host_name", rv); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'dns_host_name' argument value:%v err:%w", rv, err)
+			}
+		case "service_principal_names":
+			rv := e.GetAttributeValues("servicePrincipalName")
+			if err := d.Set("service_principal_names", schema.NewSet(lowercaseHashString, stringListToInterfaces(rv))); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'service_principal_names' argument value:%v err:%w", rv, err)
+			}
+		case "managed_password_interval":
+			rv := e.GetAttributeValue("msDS-ManagedPasswordInterval")
+			if rv == "" {
+				continue
+			}
+			interval, err := strconv.Atoi(rv)
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to parse 'msDS-ManagedPasswordInterval' value:%v err:%w", rv, err)
+			}
+			if err := d.Set("managed_password_interval", interval); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'managed_password_interval' argument value:%v err:%w", interval, err)
+			}
+		case "supported_encryption_types":
+			rv, err := decodeEncryptionTypes(e.GetAttributeValue("msDS-SupportedEncryptionTypes"))
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to convert 'msDS-SupportedEncryptionTypes' value err:%w", err)
+			}
+			if err := d.Set("supported_encryption_types", schema.NewSet(schema.HashString, stringListToInterfaces(rv))); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'supported_encryption_types' argument value:%v err:%w", rv, err)
+			}
 
 		// group object attributes
 		case "scope", "type":
@@ -152,6 +271,25 @@ func updateObjectSchema(resourceSchema map[string]*schema.Schema, e *ldap.Entry,
 				return fmt.Errorf("updateObjectSchema: unable to update 'ou' argument value:%v err:%w", rv, err)
 			}
 
+		// generic object attributes
+		case "object_class":
+			var configured []string
+			for _, v := range d.Get("object_class").([]interface{}) {
+				configured = append(configured, v.(string))
+			}
+			classes := flattenObjectClasses(configured, e.GetAttributeValues("objectClass"))
+			if err := d.Set("object_class", classes); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'object_class' argument value:%v err:%w", classes, err)
+			}
+		case "rdn_attribute":
+			rdn, err := getRDNAttribute(e.DN)
+			if err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to parse dn:%s err:%w", e.DN, err)
+			}
+			if err := d.Set("rdn_attribute", rdn); err != nil {
+				return fmt.Errorf("updateObjectSchema: unable to update 'rdn_attribute' argument value:%v err:%w", rdn, err)
+			}
+
 		// group membership attributes
 		case "group_dn":
 			rDN := e.GetAttributeValue("distinguishedName")
@@ -0,0 +1,718 @@
+package activedirectory
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// security descriptor control flags
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-dtyp/7d4dac05-9cef-4563-a058-f108abecce1d
+const (
+	sdControlDACLPresent  uint16 = 0x0004
+	sdControlSelfRelative uint16 = 0x8000
+)
+
+// ACL revisions, ACL_REVISION_DS is required if ACL contains object ACEs
+const (
+	aclRevision   byte = 0x02
+	aclRevisionDS byte = 0x04
+)
+
+// ACE types
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-dtyp/628ebb1d-c509-4ea0-a10f-77ef97ca4586
+const (
+	aceTypeAccessAllowed       byte = 0x00
+	aceTypeAccessDenied        byte = 0x01
+	aceTypeAccessAllowedObject byte = 0x05
+	aceTypeAccessDeniedObject  byte = 0x06
+)
+
+// ACE flags
+const (
+	aceFlagObjectInherit    byte = 0x01
+	aceFlagContainerInherit byte = 0x02
+	aceFlagNoPropagate      byte = 0x04
+	aceFlagInheritOnly      byte = 0x08
+	aceFlagInherited        byte = 0x10
+)
+
+// object ACE flags, specifies which of the object type GUIDs are present in ACE
+const (
+	aceObjectTypePresent          uint32 = 0x1
+	aceInheritedObjectTypePresent uint32 = 0x2
+)
+
+// access mask values for directory service objects
+// https://docs.microsoft.com/en-us/windows/win32/api/iads/ne-iads-ads_rights_enum
+const (
+	adsRightDSCreateChild        uint32 = 0x1
+	adsRightDSDeleteChild        uint32 = 0x2
+	adsRightActrlDSList          uint32 = 0x4
+	adsRightDSSelf               uint32 = 0x8
+	adsRightDSReadProp           uint32 = 0x10
+	adsRightDSWriteProp          uint32 = 0x20
+	adsRightDSDeleteTree         uint32 = 0x40
+	adsRightDSListObject         uint32 = 0x80
+	adsRightDSControlAccess      uint32 = 0x100
+	adsRightDelete               uint32 = 0x10000
+	adsRightReadControl          uint32 = 0x20000
+	adsRightWriteDAC             uint32 = 0x40000
+	adsRightWriteOwner           uint32 = 0x80000
+	adsRightSynchronize          uint32 = 0x100000
+	adsRightAccessSystemSecurity uint32 = 0x1000000
+	adsRightGenericRead          uint32 = 0x80000000
+	adsRightGenericWrite         uint32 = 0x40000000
+	adsRightGenericExecute       uint32 = 0x20000000
+	adsRightGenericAll           uint32 = 0x10000000
+)
+
+// groupMSAMembershipAccessMask is access mask of ACEs in msDS-GroupMSAMembership which allows principal to
+// retrieve managed password, it's the same value which is set by New-ADServiceAccount.
+const groupMSAMembershipAccessMask uint32 = 0xf01ff
+
+// well known SIDs and extended rights
+const (
+	sidEveryone              = "S-1-1-0"
+	sidSelf                  = "S-1-5-10"
+	sidBuiltinAdministrators = "S-1-5-32-544"
+
+	extendedRightChangePassword = "ab721a53-1e2f-11d0-9819-00aa0040529b"
+)
+
+// securityDescriptor represents self-relative security descriptor used by nTSecurityDescriptor attribute.
+// owner and group SIDs are kept in raw format since they are not managed by provider.
+type securityDescriptor struct {
+	revision byte
+	sbz1     byte
+	control  uint16
+	owner    []byte
+	group    []byte
+	sacl     *acl
+	dacl     *acl
+}
+
+type acl struct {
+	revision byte
+	aces     []ace
+}
+
+// ace represents access control entry, ACE types which are not supported are kept in raw format.
+type ace struct {
+	aceType             byte
+	flags               byte
+	mask                uint32
+	objectType          string
+	inheritedObjectType string
+	sid                 string
+	raw                 []byte
+}
+
+func (a ace) isObjectACE() bool {
+	return a.aceType == aceTypeAccessAllowedObject || a.aceType == aceTypeAccessDeniedObject
+}
+
+func (a ace) isDeny() bool {
+	return a.aceType == aceTypeAccessDenied || a.aceType == aceTypeAccessDeniedObject
+}
+
+func (a ace) isInherited() bool {
+	return a.flags&aceFlagInherited != 0
+}
+
+// equal compares ACEs ignoring case of GUIDs and SIDs
+func (a ace) equal(b ace) bool {
+	return a.aceType == b.aceType &&
+		a.flags == b.flags &&
+		a.mask == b.mask &&
+		strings.EqualFold(a.objectType, b.objectType) &&
+		strings.EqualFold(a.inheritedObjectType, b.inheritedObjectType) &&
+		strings.EqualFold(a.sid, b.sid) &&
+		string(a.raw) == string(b.raw)
+}
+
+// addACE adds ACE to the ACL in canonical order, explicit deny ACEs first, followed by explicit allow ACEs
+// and inherited ACEs at the end. ACE is not added if ACL already contains it.
+func (l *acl) addACE(n ace) {
+	pos := len(l.aces)
+	for i, a := range l.aces {
+		if a.equal(n) {
+			return
+		}
+		if pos != len(l.aces) {
+			continue
+		}
+		if a.isInherited() || (n.isDeny() && !a.isDeny()) {
+			pos = i
+		}
+	}
+	l.aces = append(l.aces, ace{})
+	copy(l.aces[pos+1:], l.aces[pos:])
+	l.aces[pos] = n
+	if n.isObjectACE() {
+		l.revision = aclRevisionDS
+	}
+}
+
+// removeACEs removes all ACEs for which match returns true and returns number of removed ACEs.
+func (l *acl) removeACEs(match func(ace) bool) int {
+	var aces []ace
+	for _, a := range l.aces {
+		if !match(a) {
+			aces = append(aces, a)
+		}
+	}
+	removed := len(l.aces) - len(aces)
+	l.aces = aces
+	return removed
+}
+
+// hasACE checks if ACL contains ACE for which match returns true.
+func (l *acl) hasACE(match func(ace) bool) bool {
+	for _, a := range l.aces {
+		if match(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSecurityDescriptor(b []byte) (*securityDescriptor, error) {
+	if len(b) < 20 {
+		return nil, fmt.Errorf("unable to decode security descriptor, min req 20 bytes given:%d", len(b))
+	}
+
+	sd := &securityDescriptor{
+		revision: b[0],
+		sbz1:     b[1],
+		control:  binary.LittleEndian.Uint16(b[2:]),
+	}
+	offsetOwner := binary.LittleEndian.Uint32(b[4:])
+	offsetGroup := binary.LittleEndian.Uint32(b[8:])
+	offsetSACL := binary.LittleEndian.Uint32(b[12:])
+	offsetDACL := binary.LittleEndian.Uint32(b[16:])
+
+	var err error
+	if sd.owner, err = rawSIDAt(b, offsetOwner); err != nil {
+		return nil, fmt.Errorf("unable to decode owner SID err:%w", err)
+	}
+	if sd.group, err = rawSIDAt(b, offsetGroup); err != nil {
+		return nil, fmt.Errorf("unable to decode group SID err:%w", err)
+	}
+	if offsetSACL != 0 {
+		if sd.sacl, err = decodeACL(b, offsetSACL); err != nil {
+			return nil, fmt.Errorf("unable to decode SACL err:%w", err)
+		}
+	}
+	if offsetDACL != 0 {
+		if sd.dacl, err = decodeACL(b, offsetDACL); err != nil {
+			return nil, fmt.Errorf("unable to decode DACL err:%w", err)
+		}
+	}
+	return sd, nil
+}
+
+func (sd *securityDescriptor) encode() ([]byte, error) {
+	b := make([]byte, 20)
+	b[0] = sd.revision
+	b[1] = sd.sbz1
+	binary.LittleEndian.PutUint16(b[2:], sd.control|sdControlSelfRelative)
+
+	if len(sd.owner) > 0 {
+		binary.LittleEndian.PutUint32(b[4:], uint32(len(b)))
+		b = append(b, sd.owner...)
+	}
+	if len(sd.group) > 0 {
+		binary.LittleEndian.PutUint32(b[8:], uint32(len(b)))
+		b = append(b, sd.group...)
+	}
+	if sd.sacl != nil {
+		rawACL, err := sd.sacl.encode()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode SACL err:%w", err)
+		}
+		binary.LittleEndian.PutUint32(b[12:], uint32(len(b)))
+		b = append(b, rawACL...)
+	}
+	if sd.dacl != nil {
+		rawACL, err := sd.dacl.encode()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode DACL err:%w", err)
+		}
+		binary.LittleEndian.PutUint32(b[16:], uint32(len(b)))
+		b = append(b, rawACL...)
+	}
+	return b, nil
+}
+
+func decodeACL(b []byte, offset uint32) (*acl, error) {
+	if int(offset)+8 > len(b) {
+		return nil, fmt.Errorf("ACL offset:%d is out of range", offset)
+	}
+	b = b[offset:]
+	size := int(binary.LittleEndian.Uint16(b[2:]))
+	count := int(binary.LittleEndian.Uint16(b[4:]))
+	if size > len(b) {
+		return nil, fmt.Errorf("ACL size:%d is out of range", size)
+	}
+
+	l := &acl{revision: b[0]}
+	pos := 8
+	for i := 0; i < count; i++ {
+		if pos+4 > size {
+			return nil, fmt.Errorf("ACE:%d header is out of range", i)
+		}
+		aceSize := int(binary.LittleEndian.Uint16(b[pos+2:]))
+		if aceSize < 4 || pos+aceSize > size {
+			return nil, fmt.Errorf("ACE:%d size:%d is out of range", i, aceSize)
+		}
+		a, err := decodeACE(b[pos : pos+aceSize])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode ACE:%d err:%w", i, err)
+		}
+		l.aces = append(l.aces, a)
+		pos += aceSize
+	}
+	return l, nil
+}
+
+func (l *acl) encode() ([]byte, error) {
+	b := make([]byte, 8)
+	b[0] = l.revision
+	if b[0] == 0 {
+		b[0] = aclRevision
+	}
+	for _, a := range l.aces {
+		rawACE, err := a.encode()
+		if err != nil {
+			return nil, err
+		}
+		if a.isObjectACE() {
+			b[0] = aclRevisionDS
+		}
+		b = append(b, rawACE...)
+	}
+	binary.LittleEndian.PutUint16(b[2:], uint16(len(b)))
+	binary.LittleEndian.PutUint16(b[4:], uint16(len(l.aces)))
+	return b, nil
+}
+
+func decodeACE(b []byte) (ace, error) {
+	a := ace{aceType: b[0], flags: b[1]}
+
+	pos := 8
+	switch a.aceType {
+	case aceTypeAccessAllowed, aceTypeAccessDenied:
+		if len(b) < 8 {
+			return a, fmt.Errorf("ACE is too small size:%d", len(b))
+		}
+		a.mask = binary.LittleEndian.Uint32(b[4:])
+	case aceTypeAccessAllowedObject, aceTypeAccessDeniedObject:
+		if len(b) < 12 {
+			return a, fmt.Errorf("object ACE is too small size:%d", len(b))
+		}
+		a.mask = binary.LittleEndian.Uint32(b[4:])
+		objectFlags := binary.LittleEndian.Uint32(b[8:])
+		pos = 12
+		var err error
+		if objectFlags&aceObjectTypePresent != 0 {
+			if pos+16 > len(b) {
+				return a, fmt.Errorf("object type of ACE is out of range")
+			}
+			if a.objectType, err = decodeGUID(b[pos : pos+16]); err != nil {
+				return a, err
+			}
+			pos += 16
+		}
+		if objectFlags&aceInheritedObjectTypePresent != 0 {
+			if pos+16 > len(b) {
+				return a, fmt.Errorf("inherited object type of ACE is out of range")
+			}
+			if a.inheritedObjectType, err = decodeGUID(b[pos : pos+16]); err != nil {
+				return a, err
+			}
+			pos += 16
+		}
+	default:
+		a.raw = append([]byte{}, b...)
+		return a, nil
+	}
+
+	rawSID, err := rawSIDAt(b, uint32(pos))
+	if err != nil {
+		return a, err
+	}
+	if a.sid, err = decodeSID(rawSID); err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+func (a ace) encode() ([]byte, error) {
+	if a.raw != nil {
+		return a.raw, nil
+	}
+
+	rawSID, err := rawSIDFromString(a.sid)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 8)
+	b[0] = a.aceType
+	b[1] = a.flags
+	binary.LittleEndian.PutUint32(b[4:], a.mask)
+
+	if a.isObjectACE() {
+		var objectFlags uint32
+		var guids []byte
+		if a.objectType != "" {
+			g, err := rawGUIDFromString(a.objectType)
+			if err != nil {
+				return nil, err
+			}
+			objectFlags |= aceObjectTypePresent
+			guids = append(guids, g...)
+		}
+		if a.inheritedObjectType != "" {
+			g, err := rawGUIDFromString(a.inheritedObjectType)
+			if err != nil {
+				return nil, err
+			}
+			objectFlags |= aceInheritedObjectTypePresent
+			guids = append(guids, g...)
+		}
+		flags := make([]byte, 4)
+		binary.LittleEndian.PutUint32(flags, objectFlags)
+		b = append(b, flags...)
+		b = append(b, guids...)
+	}
+
+	b = append(b, rawSID...)
+	binary.LittleEndian.PutUint16(b[2:], uint16(len(b)))
+	return b, nil
+}
+
+// rawSIDAt returns raw SID starting at given offset, nil is returned for offset 0.
+func rawSIDAt(b []byte, offset uint32) ([]byte, error) {
+	if offset == 0 {
+		return nil, nil
+	}
+	if int(offset)+8 > len(b) {
+		return nil, fmt.Errorf("SID offset:%d is out of range", offset)
+	}
+	size := 8 + 4*int(b[offset+1])
+	if int(offset)+size > len(b) {
+		return nil, fmt.Errorf("SID size:%d is out of range", size)
+	}
+	return b[offset : int(offset)+size], nil
+}
+
+func rawSIDFromString(sid string) ([]byte, error) {
+	hexSID, err := encodeSID(sid)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(hexSID)
+}
+
+func rawGUIDFromString(guid string) ([]byte, error) {
+	hexGUID, err := encodeGUID(guid)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(hexGUID)
+}
+
+// changePasswordACEs returns object ACEs for 'Change Password' extended right of Everyone and SELF.
+func changePasswordACEs(aceType byte) []ace {
+	var aces []ace
+	for _, sid := range []string{sidEveryone, sidSelf} {
+		aces = append(aces, ace{
+			aceType:    aceType,
+			mask:       adsRightDSControlAccess,
+			objectType: extendedRightChangePassword,
+			sid:        sid,
+		})
+	}
+	return aces
+}
+
+// isChangePasswordDenied checks if DACL denies 'Change Password' extended right to Everyone and SELF.
+func isChangePasswordDenied(dacl *acl) bool {
+	if dacl == nil {
+		return false
+	}
+	for _, n := range changePasswordACEs(aceTypeAccessDeniedObject) {
+		if !dacl.hasACE(func(a ace) bool { return !a.isInherited() && a.equal(n) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// setChangePasswordDenied replaces explicit ACEs of 'Change Password' extended right of Everyone and SELF
+// with deny ACEs if deny is true otherwise with allow ACEs.
+func setChangePasswordDenied(dacl *acl, deny bool) {
+	dacl.removeACEs(func(a ace) bool {
+		return !a.isInherited() && a.isObjectACE() && a.flags == 0 &&
+			strings.EqualFold(a.objectType, extendedRightChangePassword) &&
+			(strings.EqualFold(a.sid, sidEveryone) || strings.EqualFold(a.sid, sidSelf))
+	})
+
+	aceType := aceTypeAccessAllowedObject
+	if deny {
+		aceType = aceTypeAccessDeniedObject
+	}
+	for _, a := range changePasswordACEs(aceType) {
+		dacl.addACE(a)
+	}
+}
+
+// groupMSAMembershipSecurityDescriptor returns security descriptor of msDS-GroupMSAMembership attribute
+// which allows given SIDs to retrieve managed password of the gMSA, owner is BUILTIN\Administrators.
+func groupMSAMembershipSecurityDescriptor(sids []string) (*securityDescriptor, error) {
+	owner, err := rawSIDFromString(sidBuiltinAdministrators)
+	if err != nil {
+		return nil, err
+	}
+	sd := &securityDescriptor{
+		revision: 1,
+		control:  sdControlDACLPresent,
+		owner:    owner,
+		dacl:     &acl{revision: aclRevision},
+	}
+	for _, sid := range sids {
+		sd.dacl.addACE(ace{aceType: aceTypeAccessAllowed, mask: groupMSAMembershipAccessMask, sid: sid})
+	}
+	return sd, nil
+}
+
+// groupMSAMembershipSIDs returns SIDs which are allowed to retrieve managed password by msDS-GroupMSAMembership.
+func groupMSAMembershipSIDs(sd *securityDescriptor) []string {
+	var sids []string
+	if sd == nil || sd.dacl == nil {
+		return sids
+	}
+	for _, a := range sd.dacl.aces {
+		if a.aceType == aceTypeAccessAllowed && a.mask&groupMSAMembershipAccessMask == groupMSAMembershipAccessMask {
+			sids = append(sids, a.sid)
+		}
+	}
+	return sids
+}
+
+// aceRights maps rights names, which are the names of .NET ActiveDirectoryRights enum in snake case, to access mask values.
+var aceRights = map[string]uint32{
+	"create_child":           adsRightDSCreateChild,
+	"delete_child":           adsRightDSDeleteChild,
+	"list_children":          adsRightActrlDSList,
+	"self":                   adsRightDSSelf,
+	"read_property":          adsRightDSReadProp,
+	"write_property":         adsRightDSWriteProp,
+	"delete_tree":            adsRightDSDeleteTree,
+	"list_object":            adsRightDSListObject,
+	"extended_right":         adsRightDSControlAccess,
+	"delete":                 adsRightDelete,
+	"read_control":           adsRightReadControl,
+	"write_dacl":             adsRightWriteDAC,
+	"write_owner":            adsRightWriteOwner,
+	"synchronize":            adsRightSynchronize,
+	"access_system_security": adsRightAccessSystemSecurity,
+	"generic_read":           adsRightGenericRead,
+	"generic_write":          adsRightGenericWrite,
+	"generic_execute":        adsRightGenericExecute,
+	"generic_all":            adsRightGenericAll,
+}
+
+// genericRightsMapping maps generic rights to specific rights of directory service objects,
+// AD stores mapped rights in nTSecurityDescriptor.
+var genericRightsMapping = map[uint32]uint32{
+	adsRightGenericRead:    adsRightReadControl | adsRightActrlDSList | adsRightDSReadProp | adsRightDSListObject,
+	adsRightGenericWrite:   adsRightReadControl | adsRightDSSelf | adsRightDSWriteProp,
+	adsRightGenericExecute: adsRightReadControl | adsRightActrlDSList,
+	adsRightGenericAll: adsRightDelete | adsRightReadControl | adsRightWriteDAC | adsRightWriteOwner |
+		adsRightDSCreateChild | adsRightDSDeleteChild | adsRightActrlDSList | adsRightDSSelf | adsRightDSReadProp |
+		adsRightDSWriteProp | adsRightDSDeleteTree | adsRightDSListObject | adsRightDSControlAccess,
+}
+
+// objectTypeGUIDs maps friendly names of common classes, attributes, property sets, validated writes and
+// extended rights to GUIDs used in object type of ACEs. names are LDAP display names of classes and attributes
+// and CN of property sets and control access rights, lookup is case insensitive.
+var objectTypeGUIDs = map[string]string{
+	// classes
+	"user":                            "bf967aba-0de6-11d0-a285-00aa003049e2",
+	"group":                           "bf967a9c-0de6-11d0-a285-00aa003049e2",
+	"computer":                        "bf967a86-0de6-11d0-a285-00aa003049e2",
+	"contact":                         "5cb41ed0-0e4c-11d0-a286-00aa003049e2",
+	"organizationalunit":              "bf967aa5-0de6-11d0-a285-00aa003049e2",
+	"inetorgperson":                   "4828cc14-1437-45bc-9b07-ad6f015e5f28",
+	"msds-groupmanagedserviceaccount": "7b8b558a-93a5-4af7-adca-c017e67f1057",
+	// attributes
+	"accountexpires":       "bf967915-0de6-11d0-a285-00aa003049e2",
+	"description":          "bf967950-0de6-11d0-a285-00aa003049e2",
+	"displayname":          "bf967953-0de6-11d0-a285-00aa003049e2",
+	"dnshostname":          "72e39547-7b18-11d1-adef-00c04fd8d5cd",
+	"givenname":            "f0f8ff8e-1191-11d0-a060-00aa006c33ed",
+	"gplink":               "f30e3bbe-9ff0-11d1-b603-0000f80367c1",
+	"gpoptions":            "f30e3bbf-9ff0-11d1-b603-0000f80367c1",
+	"lockouttime":          "28630ebf-41d5-11d1-a9c1-0000f80367c1",
+	"mail":                 "bf967961-0de6-11d0-a285-00aa003049e2",
+	"managedby":            "0296c120-40da-11d1-a9c0-0000f80367c1",
+	"member":               "bf9679c0-0de6-11d0-a285-00aa003049e2",
+	"pwdlastset":           "bf967a0a-0de6-11d0-a285-00aa003049e2",
+	"samaccountname":       "3e0abfd0-126a-11d0-a060-00aa006c33ed",
+	"serviceprincipalname": "f3a64788-5306-11d1-a9c5-0000f80367c1",
+	"sn":                   "bf967a41-0de6-11d0-a285-00aa003049e2",
+	"telephonenumber":      "bf967a49-0de6-11d0-a285-00aa003049e2",
+	"useraccountcontrol":   "bf967a68-0de6-11d0-a285-00aa003049e2",
+	"userprincipalname":    "28630ebb-41d5-11d1-a9c1-0000f80367c1",
+	"msds-allowedtoactonbehalfofotheridentity": "3f78c3e5-f79a-46bd-a0b8-9d18116ddc79",
+	// property sets
+	"email-information":         "e45795b2-9455-11d1-aebd-0000f80367c1",
+	"general-information":       "59ba2f42-79a2-11d0-9020-00c04fc2d3cf",
+	"membership":                "bc0ac240-79a9-11d0-9020-00c04fc2d4cf",
+	"personal-information":      "77b5b886-944a-11d1-aebd-0000f80367c1",
+	"public-information":        "e48d0154-bcf8-11d1-8702-00c04fb96050",
+	"user-account-restrictions": "4c164200-20c0-11d0-a768-00aa006e0529",
+	"user-logon":                "5f202010-79a5-11d0-9020-00c04fc2d4cf",
+	"web-information":           "e45795b3-9455-11d1-aebd-0000f80367c1",
+	// validated writes
+	"self-membership":         "bf9679c0-0de6-11d0-a285-00aa003049e2",
+	"validated-dns-host-name": "72e39547-7b18-11d1-adef-00c04fd8d5cd",
+	"validated-spn":           "f3a64788-5306-11d1-a9c5-0000f80367c1",
+	// extended rights
+	"allowed-to-authenticate":        "68b1d179-0d15-4d4f-ab71-46152e79a7bc",
+	"ds-replication-get-changes":     "1131f6aa-9c07-11d1-f79f-00c04fc2dcd2",
+	"ds-replication-get-changes-all": "1131f6ad-9c07-11d1-f79f-00c04fc2dcd2",
+	"receive-as":                     "ab721a56-1e2f-11d0-9819-00aa0040529b",
+	"send-as":                        "ab721a54-1e2f-11d0-9819-00aa0040529b",
+	"user-change-password":           extendedRightChangePassword,
+	"user-force-change-password":     "00299570-246d-11d0-a768-00aa006e0529",
+}
+
+// aceInheritanceFlags maps inheritance names, which are the names of .NET ActiveDirectorySecurityInheritance enum
+// in snake case, to ACE flags.
+var aceInheritanceFlags = map[string]byte{
+	"none":              0,
+	"all":               aceFlagContainerInherit,
+	"descendents":       aceFlagContainerInherit | aceFlagInheritOnly,
+	"self_and_children": aceFlagContainerInherit | aceFlagNoPropagate,
+	"children":          aceFlagContainerInherit | aceFlagNoPropagate | aceFlagInheritOnly,
+}
+
+// rightsToMask converts rights names or hexadecimal masks ie '0x100' to access mask, generic rights are
+// mapped to specific rights the same way AD stores them.
+func rightsToMask(rights []string) (uint32, error) {
+	var mask uint32
+	for _, r := range rights {
+		if m, ok := aceRights[strings.ToLower(r)]; ok {
+			mask |= m
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(r), "0x") {
+			return 0, fmt.Errorf("unknown access right %s", r)
+		}
+		m, err := strconv.ParseUint(r[2:], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse access mask %s err:%w", r, err)
+		}
+		mask |= uint32(m)
+	}
+	for generic, specific := range genericRightsMapping {
+		if mask&generic != 0 {
+			mask = mask&^generic | specific
+		}
+	}
+	return mask, nil
+}
+
+// maskToRights converts access mask to sorted rights names, 'generic_all' is used if mask contains all its rights
+// and bits which don't have a name are returned as hexadecimal mask.
+func maskToRights(mask uint32) []string {
+	var rights []string
+	if all := genericRightsMapping[adsRightGenericAll]; mask&all == all {
+		rights = append(rights, "generic_all")
+		mask &^= all
+	}
+	for name, m := range aceRights {
+		if genericRightsMapping[m] != 0 {
+			continue
+		}
+		if mask&m != 0 {
+			rights = append(rights, name)
+			mask &^= m
+		}
+	}
+	if mask != 0 {
+		rights = append(rights, fmt.Sprintf("0x%x", mask))
+	}
+	sort.Strings(rights)
+	return rights
+}
+
+// flattenRights returns rights for the state, configured rights are kept if they represent the same access mask.
+func flattenRights(mask uint32, configured []string) []string {
+	if m, err := rightsToMask(configured); err == nil && len(configured) > 0 && m == mask {
+		return configured
+	}
+	return maskToRights(mask)
+}
+
+// resolveObjectType returns GUID of given object type which is either GUID or friendly name.
+func resolveObjectType(v string) (string, error) {
+	if v == "" || isGUIDString(v) {
+		return v, nil
+	}
+	if guid, ok := objectTypeGUIDs[strings.ToLower(v)]; ok {
+		return guid, nil
+	}
+	return "", fmt.Errorf("unknown object type %s, it should be GUID or one of known names", v)
+}
+
+// flattenObjectType returns object type for the state, configured value is kept if it represents the same GUID.
+func flattenObjectType(guid, configured string) string {
+	if g, err := resolveObjectType(configured); err == nil && strings.EqualFold(g, guid) {
+		return configured
+	}
+	return guid
+}
+
+// aceInheritance returns inheritance name of ACE flags, object inherit flag is ignored since it's not used by AD objects.
+func aceInheritance(flags byte) string {
+	flags &= aceFlagContainerInherit | aceFlagNoPropagate | aceFlagInheritOnly
+	if flags&aceFlagContainerInherit == 0 {
+		return "none"
+	}
+	for name, f := range aceInheritanceFlags {
+		if f == flags {
+			return name
+		}
+	}
+	return "none"
+}
+
+// newACE returns ACE of given values, object ACE type is used if object type or inherited object type is set.
+func newACE(deny bool, mask uint32, objectType, inheritedObjectType, sid, inheritance string) ace {
+	a := ace{
+		aceType:             aceTypeAccessAllowed,
+		flags:               aceInheritanceFlags[inheritance],
+		mask:                mask,
+		objectType:          objectType,
+		inheritedObjectType: inheritedObjectType,
+		sid:                 sid,
+	}
+	isObject := objectType != "" || inheritedObjectType != ""
+	switch {
+	case deny && isObject:
+		a.aceType = aceTypeAccessDeniedObject
+	case deny:
+		a.aceType = aceTypeAccessDenied
+	case isObject:
+		a.aceType = aceTypeAccessAllowedObject
+	}
+	return a
+}
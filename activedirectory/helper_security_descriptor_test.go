@@ -0,0 +1,297 @@
+package activedirectory
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+// testSecurityDescriptor contains DACL with explicit deny object ACE, explicit allow ACE
+// and inherited allow object ACE with both object type and inherited object type
+const testSecurityDescriptor = "010004800000000000000000000000001400000004008c0003000000060028000001000001000000531a72ab2f1ed011981900aa0040529b01010000000000010000000000002400ff010f00010500000000000515000000dcf4dc3b833d2b46828ba62800020000051238003000000003000000867a96bfe60dd011a28500aa003049e2ba7a96bfe60dd011a28500aa003049e201010000000000050a000000"
+
+func Test_decodeSecurityDescriptor(t *testing.T) {
+	raw, _ := hex.DecodeString(testSecurityDescriptor)
+	want := []ace{
+		{aceType: aceTypeAccessDeniedObject, mask: adsRightDSControlAccess, objectType: extendedRightChangePassword, sid: sidEveryone},
+		{aceType: aceTypeAccessAllowed, mask: 0xf01ff, sid: "S-1-5-21-1004336348-1177238915-682003330-512"},
+		{aceType: aceTypeAccessAllowedObject, flags: aceFlagContainerInherit | aceFlagInherited, mask: 0x30, objectType: "bf967a86-0de6-11d0-a285-00aa003049e2", inheritedObjectType: "bf967aba-0de6-11d0-a285-00aa003049e2", sid: sidSelf},
+	}
+
+	sd, err := decodeSecurityDescriptor(raw)
+	if err != nil {
+		t.Fatalf("decodeSecurityDescriptor() error = %v", err)
+	}
+	if sd.owner != nil || sd.group != nil || sd.sacl != nil {
+		t.Errorf("decodeSecurityDescriptor() only DACL expected, got owner:%x group:%x sacl:%v", sd.owner, sd.group, sd.sacl)
+	}
+	if sd.dacl == nil || len(sd.dacl.aces) != len(want) {
+		t.Fatalf("decodeSecurityDescriptor() Got DACL = %#v, want %d ACEs", sd.dacl, len(want))
+	}
+	for i, a := range sd.dacl.aces {
+		if !a.equal(want[i]) {
+			t.Errorf("decodeSecurityDescriptor() ACE = %d Got = %#v, want %#v", i, a, want[i])
+		}
+	}
+
+	encoded, err := sd.encode()
+	if err != nil {
+		t.Fatalf("securityDescriptor.encode() error = %v", err)
+	}
+	if hex.EncodeToString(encoded) != testSecurityDescriptor {
+		t.Errorf("securityDescriptor.encode() Got = %x, want %v", encoded, testSecurityDescriptor)
+	}
+
+	if _, err := decodeSecurityDescriptor(raw[:40]); err == nil {
+		t.Errorf("decodeSecurityDescriptor() truncated descriptor error expected")
+	}
+}
+
+func Test_acl_addACE(t *testing.T) {
+	deny := ace{aceType: aceTypeAccessDenied, mask: 1, sid: sidEveryone}
+	allow := ace{aceType: aceTypeAccessAllowed, mask: 1, sid: sidEveryone}
+	inherited := ace{aceType: aceTypeAccessAllowed, flags: aceFlagInherited, mask: 1, sid: sidSelf}
+	objDeny := ace{aceType: aceTypeAccessDeniedObject, mask: 1, objectType: extendedRightChangePassword, sid: sidSelf}
+	objAllow := ace{aceType: aceTypeAccessAllowedObject, mask: 1, objectType: extendedRightChangePassword, sid: sidSelf}
+
+	tests := []struct {
+		name string
+		aces []ace
+		add  ace
+		want []ace
+	}{
+		{name: "1", aces: nil, add: allow, want: []ace{allow}},
+		{name: "2", aces: []ace{allow, inherited}, add: deny, want: []ace{deny, allow, inherited}},
+		{name: "3", aces: []ace{deny, inherited}, add: allow, want: []ace{deny, allow, inherited}},
+		{name: "4", aces: []ace{deny, allow, inherited}, add: objDeny, want: []ace{deny, objDeny, allow, inherited}},
+		{name: "5", aces: []ace{deny, allow, inherited}, add: objAllow, want: []ace{deny, allow, objAllow, inherited}},
+		{name: "6", aces: []ace{deny, allow}, add: allow, want: []ace{deny, allow}},
+	}
+	for _, tt := range tests {
+		l := &acl{aces: tt.aces}
+		l.addACE(tt.add)
+		if !reflect.DeepEqual(l.aces, tt.want) {
+			t.Errorf("acl.addACE() name = %s Got = %v, want %v", tt.name, l.aces, tt.want)
+		}
+	}
+}
+
+func Test_setChangePasswordDenied(t *testing.T) {
+	raw, _ := hex.DecodeString(testSecurityDescriptor)
+	sd, err := decodeSecurityDescriptor(raw)
+	if err != nil {
+		t.Fatalf("decodeSecurityDescriptor() error = %v", err)
+	}
+	// test descriptor only denies Everyone
+	if isChangePasswordDenied(sd.dacl) {
+		t.Errorf("isChangePasswordDenied() Got = true, want false")
+	}
+
+	setChangePasswordDenied(sd.dacl, true)
+	if !isChangePasswordDenied(sd.dacl) {
+		t.Errorf("isChangePasswordDenied() after deny Got = false, want true")
+	}
+	if len(sd.dacl.aces) != 4 {
+		t.Errorf("setChangePasswordDenied() deny Got = %d ACEs, want 4", len(sd.dacl.aces))
+	}
+
+	setChangePasswordDenied(sd.dacl, false)
+	if isChangePasswordDenied(sd.dacl) {
+		t.Errorf("isChangePasswordDenied() after allow Got = true, want false")
+	}
+	for _, a := range changePasswordACEs(aceTypeAccessAllowedObject) {
+		if !sd.dacl.hasACE(a.equal) {
+			t.Errorf("setChangePasswordDenied() allow ACE for %s is missing", a.sid)
+		}
+	}
+	if len(sd.dacl.aces) != 4 {
+		t.Errorf("setChangePasswordDenied() allow Got = %d ACEs, want 4", len(sd.dacl.aces))
+	}
+}
+
+func Test_groupMSAMembershipSecurityDescriptor(t *testing.T) {
+	sids := []string{"S-1-5-21-1004336348-1177238915-682003330-1104", "S-1-5-21-1004336348-1177238915-682003330-515"}
+	sd, err := groupMSAMembershipSecurityDescriptor(sids)
+	if err != nil {
+		t.Fatalf("groupMSAMembershipSecurityDescriptor() error = %v", err)
+	}
+	raw, err := sd.encode()
+	if err != nil {
+		t.Fatalf("encode() error = %v", err)
+	}
+	decoded, err := decodeSecurityDescriptor(raw)
+	if err != nil {
+		t.Fatalf("decodeSecurityDescriptor() error = %v", err)
+	}
+	owner, _ := decodeSID(decoded.owner)
+	if owner != sidBuiltinAdministrators {
+		t.Errorf("groupMSAMembershipSecurityDescriptor() owner Got = %s, want %s", owner, sidBuiltinAdministrators)
+	}
+	if got := groupMSAMembershipSIDs(decoded); !reflect.DeepEqual(got, sids) {
+		t.Errorf("groupMSAMembershipSIDs() Got = %v, want %v", got, sids)
+	}
+
+	empty, _ := groupMSAMembershipSecurityDescriptor(nil)
+	if got := groupMSAMembershipSIDs(empty); len(got) != 0 {
+		t.Errorf("groupMSAMembershipSIDs() of empty descriptor Got = %v, want []", got)
+	}
+}
+
+func Test_rightsToMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		rights  []string
+		want    uint32
+		wantErr bool
+	}{
+		{name: "1", rights: []string{"read_property", "write_property"}, want: 0x30},
+		{name: "2", rights: []string{"Extended_Right"}, want: adsRightDSControlAccess},
+		{name: "3", rights: []string{"0x100", "read_control"}, want: 0x20100},
+		{name: "4", rights: []string{"generic_all"}, want: 0xf01ff},
+		{name: "5", rights: []string{"generic_read"}, want: 0x20094},
+		{name: "6", rights: []string{"full_control"}, wantErr: true},
+		{name: "7", rights: []string{"0xzz"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := rightsToMask(tt.rights)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("rightsToMask() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("rightsToMask() name = %s Got = 0x%x, want 0x%x", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_maskToRights(t *testing.T) {
+	tests := []struct {
+		name string
+		mask uint32
+		want []string
+	}{
+		{name: "1", mask: 0x30, want: []string{"read_property", "write_property"}},
+		{name: "2", mask: 0xf01ff, want: []string{"generic_all"}},
+		{name: "3", mask: 0x1f01ff, want: []string{"generic_all", "synchronize"}},
+		{name: "4", mask: 0x20100 | 0x200, want: []string{"0x200", "extended_right", "read_control"}},
+	}
+	for _, tt := range tests {
+		if got := maskToRights(tt.mask); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("maskToRights() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_flattenRights(t *testing.T) {
+	tests := []struct {
+		name       string
+		mask       uint32
+		configured []string
+		want       []string
+	}{
+		{name: "1", mask: 0x20094, configured: []string{"generic_read"}, want: []string{"generic_read"}},
+		{name: "2", mask: 0x30, configured: []string{"write_property"}, want: []string{"read_property", "write_property"}},
+		{name: "3", mask: 0x100, configured: nil, want: []string{"extended_right"}},
+	}
+	for _, tt := range tests {
+		if got := flattenRights(tt.mask, tt.configured); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("flattenRights() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_flattenObjectType(t *testing.T) {
+	tests := []struct {
+		name       string
+		guid       string
+		configured string
+		want       string
+	}{
+		{name: "1", guid: "bf967aba-0de6-11d0-a285-00aa003049e2", configured: "User", want: "User"},
+		{name: "2", guid: "bf967aba-0de6-11d0-a285-00aa003049e2", configured: "BF967ABA-0DE6-11D0-A285-00AA003049E2", want: "BF967ABA-0DE6-11D0-A285-00AA003049E2"},
+		{name: "3", guid: "bf967a9c-0de6-11d0-a285-00aa003049e2", configured: "user", want: "bf967a9c-0de6-11d0-a285-00aa003049e2"},
+		{name: "4", guid: "", configured: "", want: ""},
+		{name: "5", guid: extendedRightChangePassword, configured: "unknown", want: extendedRightChangePassword},
+	}
+	for _, tt := range tests {
+		if got := flattenObjectType(tt.guid, tt.configured); got != tt.want {
+			t.Errorf("flattenObjectType() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_aceInheritance(t *testing.T) {
+	for name, flags := range aceInheritanceFlags {
+		if got := aceInheritance(flags | aceFlagInherited); got != name {
+			t.Errorf("aceInheritance() flags = 0x%x Got = %v, want %v", flags, got, name)
+		}
+	}
+	if got := aceInheritance(aceFlagObjectInherit); got != "none" {
+		t.Errorf("aceInheritance() object inherit only Got = %v, want none", got)
+	}
+}
+
+func Test_newACE(t *testing.T) {
+	tests := []struct {
+		name       string
+		deny       bool
+		objectType string
+		want       byte
+	}{
+		{name: "1", deny: false, objectType: "", want: aceTypeAccessAllowed},
+		{name: "2", deny: true, objectType: "", want: aceTypeAccessDenied},
+		{name: "3", deny: false, objectType: extendedRightChangePassword, want: aceTypeAccessAllowedObject},
+		{name: "4", deny: true, objectType: extendedRightChangePassword, want: aceTypeAccessDeniedObject},
+	}
+	for _, tt := range tests {
+		a := newACE(tt.deny, adsRightDSControlAccess, tt.objectType, "", sidSelf, "descendents")
+		if a.aceType != tt.want || a.flags != aceFlagContainerInherit|aceFlagInheritOnly {
+			t.Errorf("newACE() name = %s Got type = %d flags = 0x%x, want type = %d", tt.name, a.aceType, a.flags, tt.want)
+		}
+	}
+}
+
+func Test_delegationTasks(t *testing.T) {
+	for task, aces := range delegationTasks {
+		for _, a := range aces {
+			for _, name := range []string{a.objectType, a.inheritedObjectType} {
+				if _, ok := objectTypeGUIDs[name]; name != "" && !ok {
+					t.Errorf("delegationTasks task = %s unknown object type = %s", task, name)
+				}
+			}
+			if _, ok := aceInheritanceFlags[a.inheritance]; !ok {
+				t.Errorf("delegationTasks task = %s unknown inheritance = %s", task, a.inheritance)
+			}
+		}
+	}
+}
+
+func Test_delegatedTasks(t *testing.T) {
+	other := "S-1-5-21-1004336348-1177238915-682003330-1105"
+	tests := []struct {
+		name    string
+		granted []string
+		sid     string
+		want    []string
+	}{
+		{name: "1", granted: []string{"reset_user_passwords"}, sid: sidSelf, want: []string{"reset_user_passwords"}},
+		{name: "2", granted: []string{"join_computers", "manage_group_membership"}, sid: sidSelf, want: []string{"join_computers", "manage_group_membership"}},
+		{name: "3", granted: []string{"reset_user_passwords"}, sid: other, want: nil},
+		{name: "4", granted: nil, sid: sidSelf, want: nil},
+	}
+	for _, tt := range tests {
+		l := &acl{}
+		for _, a := range delegationACEs(tt.granted, sidSelf) {
+			l.addACE(a)
+		}
+		if got := delegatedTasks(l, tt.sid); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("delegatedTasks() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// ACEs which are granted by more than one task are returned once
+	aces := delegationACEs([]string{"reset_user_passwords", "reset_user_passwords"}, sidSelf)
+	if len(aces) != len(delegationTasks["reset_user_passwords"]) {
+		t.Errorf("delegationACEs() Got %d ACEs, want %d", len(aces), len(delegationTasks["reset_user_passwords"]))
+	}
+}
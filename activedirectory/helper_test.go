@@ -230,6 +230,96 @@ func Test_setaccountDisabledFlag(t *testing.T) {
 	}
 }
 
+func Test_isUACFlagSet(t *testing.T) {
+	type args struct {
+		userAccountControl string
+		flag               uint64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{name: "1", args: args{"66048", dontExpirePasswordFlag}, want: true, wantErr: false},
+		{name: "2", args: args{"512", dontExpirePasswordFlag}, want: false, wantErr: false},
+		{name: "3", args: args{"546", passwordNotRequiredFlag}, want: true, wantErr: false},
+		{name: "4", args: args{"532480", trustedForDelegationFlag}, want: true, wantErr: false},
+		{name: "5", args: args{"4194816", dontRequirePreauthFlag}, want: true, wantErr: false},
+		{name: "6", args: args{"4194816", notDelegatedFlag}, want: false, wantErr: false},
+		{name: "7", args: args{"abc", notDelegatedFlag}, want: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := isUACFlagSet(tt.args.userAccountControl, tt.args.flag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("isUACFlagSet() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			return
+		}
+		if got != tt.want {
+			t.Errorf("isUACFlagSet() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_setUACFlag(t *testing.T) {
+	type args struct {
+		userAccountControl string
+		flag               uint64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{name: "1", args: args{"512", dontExpirePasswordFlag}, want: "66048", wantErr: false},
+		{name: "2", args: args{"66048", dontExpirePasswordFlag}, want: "66048", wantErr: false},
+		{name: "3", args: args{"4096", trustedForDelegationFlag}, want: "528384", wantErr: false},
+		{name: "4", args: args{"512", smartcardRequiredFlag}, want: "262656", wantErr: false},
+		{name: "5", args: args{"512", trustedToAuthForDelegationFlag}, want: "16777728", wantErr: false},
+		{name: "6", args: args{"", useDESKeyOnlyFlag}, want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := setUACFlag(tt.args.userAccountControl, tt.args.flag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("setUACFlag() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			return
+		}
+		if got != tt.want {
+			t.Errorf("setUACFlag() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_unsetUACFlag(t *testing.T) {
+	type args struct {
+		userAccountControl string
+		flag               uint64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{name: "1", args: args{"66048", dontExpirePasswordFlag}, want: "512", wantErr: false},
+		{name: "2", args: args{"512", dontExpirePasswordFlag}, want: "512", wantErr: false},
+		{name: "3", args: args{"546", passwordNotRequiredFlag}, want: "514", wantErr: false},
+		{name: "4", args: args{"1049088", notDelegatedFlag}, want: "512", wantErr: false},
+		{name: "5", args: args{"-1", notDelegatedFlag}, want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := unsetUACFlag(tt.args.userAccountControl, tt.args.flag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("unsetUACFlag() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			return
+		}
+		if got != tt.want {
+			t.Errorf("unsetUACFlag() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
 func Test_getModifiedAttributes(t *testing.T) {
 	type args struct {
 		oldAttrMap map[string][]string
@@ -370,3 +460,1046 @@ func Test_getGroupTypeScope(t *testing.T) {
 		}
 	}
 }
+
+func Test_chunkStrings(t *testing.T) {
+	type args struct {
+		values []string
+		size   int
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]string
+	}{
+		{name: "1", args: args{nil, 2}, want: nil},
+		{name: "2", args: args{[]string{"a"}, 2}, want: [][]string{{"a"}}},
+		{name: "3", args: args{[]string{"a", "b"}, 2}, want: [][]string{{"a", "b"}}},
+		{name: "4", args: args{[]string{"a", "b", "c"}, 2}, want: [][]string{{"a", "b"}, {"c"}}},
+		{name: "5", args: args{[]string{"a", "b", "c", "d", "e"}, 2}, want: [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+	}
+	for _, tt := range tests {
+		if got := chunkStrings(tt.args.values, tt.args.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("chunkStrings() name = %s got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_parseExtendedDN(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  extendedDN
+	}{
+		{
+			name:  "1",
+			value: "<GUID=b9edea5d-2f94-4d78-87b8-6b75af7017d6>;<SID=S-1-5-21-3184940112-3977852841-221537619-1157>;CN=user1,OU=Users,DC=example,DC=com",
+			want:  extendedDN{guid: "b9edea5d-2f94-4d78-87b8-6b75af7017d6", sid: "S-1-5-21-3184940112-3977852841-221537619-1157", dn: "CN=user1,OU=Users,DC=example,DC=com"},
+		},
+		{
+			name:  "2",
+			value: "<GUID=b9edea5d-2f94-4d78-87b8-6b75af7017d6>;CN=contact1,OU=Users,DC=example,DC=com",
+			want:  extendedDN{guid: "b9edea5d-2f94-4d78-87b8-6b75af7017d6", dn: "CN=contact1,OU=Users,DC=example,DC=com"},
+		},
+		{
+			name:  "3",
+			value: "CN=user1,OU=Users,DC=example,DC=com",
+			want:  extendedDN{dn: "CN=user1,OU=Users,DC=example,DC=com"},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseExtendedDN(tt.value); got != tt.want {
+			t.Errorf("parseExtendedDN() name = %s got = %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_matchObjectIdentities(t *testing.T) {
+	remote := []string{
+		"<GUID=b9edea5d-2f94-4d78-87b8-6b75af7017d6>;<SID=S-1-5-21-3184940112-3977852841-221537619-1157>;CN=user1,OU=Users,DC=example,DC=com",
+		"<GUID=10fdfa99-a441-4e81-88cd-e98286a7b3cf>;<SID=S-1-5-21-3184940112-3977852841-221537619-1106>;CN=user2,OU=Users,DC=example,DC=com",
+		"<GUID=8992f175-e7bc-4d13-b3e1-25b3da9ac7b6>;<SID=S-1-5-21-3184940112-3977852841-221537619-1104>;CN=user3,OU=Users,DC=example,DC=com",
+		"<GUID=009ee939-7e98-4de3-8615-48dc9a866e9b>;<SID=S-1-5-21-3184940112-3977852841-221537619-1105>;CN=user4,OU=Users,DC=example,DC=com",
+		"<GUID=7c81c742-1244-4d5e-a68c-f39a5e08e5d7>;<SID=S-1-5-21-3184940112-3977852841-221537619-1107>;CN=user5,OU=Users,DC=example,DC=com",
+		"<GUID=3f5ccc18-7d90-470f-ad2b-ae6722e31953>;<SID=S-1-5-21-3184940112-3977852841-221537619-1108>;CN=user6,OU=Users,DC=example,DC=com",
+	}
+	identities := []string{
+		"B9EDEA5D-2F94-4D78-87B8-6B75AF7017D6",
+		"S-1-5-21-3184940112-3977852841-221537619-1106",
+		"cn=user3,ou=users,dc=example,dc=com",
+		"CN=deleted,OU=Users,DC=example,DC=com",
+		"User5",
+		"user6@example.com",
+	}
+	resolved := map[string]string{
+		"user5":             "CN=user5,OU=Users,DC=example,DC=com",
+		"user6@example.com": "CN=user6,OU=Users,DC=example,DC=com",
+	}
+	want := []string{
+		"B9EDEA5D-2F94-4D78-87B8-6B75AF7017D6",
+		"S-1-5-21-3184940112-3977852841-221537619-1106",
+		"cn=user3,ou=users,dc=example,dc=com",
+		"CN=user4,OU=Users,DC=example,DC=com",
+		"User5",
+		"user6@example.com",
+	}
+	if got := matchObjectIdentities(identities, resolved, remote); !reflect.DeepEqual(got, want) {
+		t.Errorf("matchObjectIdentities() got = %#v, want %#v", got, want)
+	}
+}
+
+func Test_diffDNs(t *testing.T) {
+	type args struct {
+		oldDNs []string
+		newDNs []string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{name: "1", args: args{nil, nil}, wantAdded: nil, wantRemoved: nil},
+		{name: "2", args: args{nil, []string{"CN=a,DC=example,DC=com"}}, wantAdded: []string{"CN=a,DC=example,DC=com"}, wantRemoved: nil},
+		{name: "3", args: args{[]string{"CN=a,DC=example,DC=com"}, nil}, wantAdded: nil, wantRemoved: []string{"CN=a,DC=example,DC=com"}},
+		{name: "4", args: args{[]string{"CN=a,DC=example,DC=com"}, []string{"cn=a,dc=example,dc=com"}}, wantAdded: nil, wantRemoved: nil},
+		{
+			name:        "5",
+			args:        args{[]string{"CN=a,DC=example,DC=com", "CN=b,DC=example,DC=com"}, []string{"CN=b,DC=example,DC=com", "CN=c,DC=example,DC=com", "cn=c,dc=example,dc=com"}},
+			wantAdded:   []string{"CN=c,DC=example,DC=com"},
+			wantRemoved: []string{"CN=a,DC=example,DC=com"},
+		},
+	}
+	for _, tt := range tests {
+		gotAdded, gotRemoved := diffDNs(tt.args.oldDNs, tt.args.newDNs)
+		if !reflect.DeepEqual(gotAdded, tt.wantAdded) {
+			t.Errorf("diffDNs() name = %s added = %v, want %v", tt.name, gotAdded, tt.wantAdded)
+		}
+		if !reflect.DeepEqual(gotRemoved, tt.wantRemoved) {
+			t.Errorf("diffDNs() name = %s removed = %v, want %v", tt.name, gotRemoved, tt.wantRemoved)
+		}
+	}
+}
+
+func Test_validateObjectIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "CN=user1,OU=Users,DC=example,DC=com", wantErr: false},
+		{name: "2", value: "b9edea5d-2f94-4d78-87b8-6b75af7017d6", wantErr: false},
+		{name: "3", value: "S-1-5-21-3184940112-3977852841-221537619-1157", wantErr: false},
+		{name: "4", value: "S-1-5-32-544", wantErr: false},
+		{name: "5", value: "user1", wantErr: false},
+		{name: "6", value: "server1$", wantErr: false},
+		{name: "7", value: "user1@example.com", wantErr: false},
+		{name: "8", value: "CN=user1,OU=Users,DC=example,", wantErr: true},
+		{name: "9", value: "user*", wantErr: true},
+		{name: "10", value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateObjectIdentity(tt.value, "members")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateObjectIdentity() name = %s errs = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_splitSID(t *testing.T) {
+	tests := []struct {
+		name          string
+		sid           string
+		wantDomainSID string
+		wantRID       string
+		wantErr       bool
+	}{
+		{name: "1", sid: "S-1-5-21-3184940112-3977852841-221537619-1157", wantDomainSID: "S-1-5-21-3184940112-3977852841-221537619", wantRID: "1157", wantErr: false},
+		{name: "2", sid: "S-1-5-21-3184940112-3977852841-221537619-513", wantDomainSID: "S-1-5-21-3184940112-3977852841-221537619", wantRID: "513", wantErr: false},
+		{name: "3", sid: "S-1-5-32-544", wantDomainSID: "S-1-5-32", wantRID: "544", wantErr: false},
+		{name: "4", sid: "S-1-5", wantDomainSID: "", wantRID: "", wantErr: true},
+		{name: "5", sid: "CN=group1,DC=example,DC=com", wantDomainSID: "", wantRID: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		gotDomainSID, gotRID, err := splitSID(tt.sid)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitSID() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			return
+		}
+		if gotDomainSID != tt.wantDomainSID || gotRID != tt.wantRID {
+			t.Errorf("splitSID() name = %s got = %s %s, want %s %s", tt.name, gotDomainSID, gotRID, tt.wantDomainSID, tt.wantRID)
+		}
+	}
+}
+
+func Test_validatePrimaryGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "CN=Domain Users,CN=Users,DC=example,DC=com", wantErr: false},
+		{name: "2", value: "S-1-5-21-3184940112-3977852841-221537619-513", wantErr: false},
+		{name: "3", value: "Domain Users", wantErr: true},
+		{name: "4", value: "b9edea5d-2f94-4d78-87b8-6b75af7017d6", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validatePrimaryGroup(tt.value, "primary_group")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validatePrimaryGroup() name = %s errs = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_formatFileTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "1", value: "", want: "", wantErr: false},
+		{name: "2", value: "0", want: "", wantErr: false},
+		{name: "3", value: "9223372036854775807", want: "never", wantErr: false},
+		{name: "4", value: "132539328000000000", want: "2021-01-01T00:00:00Z", wantErr: false},
+		{name: "5", value: "133802064000000000", want: "2025-01-01T12:00:00Z", wantErr: false},
+		{name: "6", value: "abc", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := formatFileTime(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("formatFileTime() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("formatFileTime() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_accountExpires(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		wantValue string
+		wantErr   bool
+	}{
+		{name: "1", value: "never", want: "0", wantValue: "never", wantErr: false},
+		{name: "2", value: "2021-01-01T00:00:00Z", want: "132539328000000000", wantValue: "2021-01-01T00:00:00Z", wantErr: false},
+		{name: "3", value: "2021-01-01T02:00:00+02:00", want: "132539328000000000", wantValue: "2021-01-01T00:00:00Z", wantErr: false},
+		{name: "4", value: "2021-01-01", wantErr: true},
+		{name: "5", value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := accountExpiresFromString(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("accountExpiresFromString() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("accountExpiresFromString() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+		back, err := accountExpiresToString(got)
+		if err != nil {
+			t.Errorf("accountExpiresToString() name = %s error = %v", tt.name, err)
+		}
+		if back != tt.wantValue {
+			t.Errorf("accountExpiresToString() name = %s Got = %v, want %v", tt.name, back, tt.wantValue)
+		}
+	}
+	if got, _ := accountExpiresToString("9223372036854775807"); got != "never" {
+		t.Errorf("accountExpiresToString() max FILETIME Got = %v, want never", got)
+	}
+}
+
+func Test_accountExpiresDiffSuppressor(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{name: "1", old: "never", new: "Never", want: true},
+		{name: "2", old: "2021-01-01T00:00:00Z", new: "2021-01-01T02:00:00+02:00", want: true},
+		{name: "3", old: "2021-01-01T00:00:00Z", new: "2021-01-01T00:00:00+02:00", want: false},
+		{name: "4", old: "never", new: "2021-01-01T00:00:00Z", want: false},
+	}
+	for _, tt := range tests {
+		if got := accountExpiresDiffSuppressor("account_expires", tt.old, tt.new, nil); got != tt.want {
+			t.Errorf("accountExpiresDiffSuppressor() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_isLockedOut(t *testing.T) {
+	// 2021-01-01T00:00:00Z
+	now := fileTimeToTime(132539328000000000)
+	type args struct {
+		lockoutTime     string
+		lockoutDuration int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{name: "1", args: args{"", -18000000000}, want: false, wantErr: false},
+		{name: "2", args: args{"0", -18000000000}, want: false, wantErr: false},
+		// locked out 10 min ago with 30 min duration
+		{name: "3", args: args{"132539322000000000", -18000000000}, want: true, wantErr: false},
+		// locked out 1 hour ago with 30 min duration
+		{name: "4", args: args{"132539292000000000", -18000000000}, want: false, wantErr: false},
+		// locked out until administrator unlocks
+		{name: "5", args: args{"132539292000000000", -9223372036854775808}, want: true, wantErr: false},
+		{name: "6", args: args{"132539292000000000", 0}, want: true, wantErr: false},
+		{name: "7", args: args{"abc", -18000000000}, want: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := isLockedOut(tt.args.lockoutTime, tt.args.lockoutDuration, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("isLockedOut() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("isLockedOut() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_tlsServerName(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "1", url: "ldap://dc1.example.com:389", want: "dc1.example.com", wantErr: false},
+		{name: "2", url: "ldap://dc1.example.com", want: "dc1.example.com", wantErr: false},
+		{name: "3", url: "ldaps://10.0.0.1:636", want: "10.0.0.1", wantErr: false},
+		{name: "4", url: "ldap://", want: "", wantErr: true},
+		{name: "5", url: "ldap://dc1 example.com", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tlsServerName(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("tlsServerName() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("tlsServerName() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_isConfidential(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   bool
+	}{
+		{name: "1", config: Config{serverURL: "ldaps://dc1.example.com:636"}, want: true},
+		{name: "2", config: Config{serverURL: "LDAPS://dc1.example.com:636"}, want: true},
+		{name: "3", config: Config{serverURL: "ldap://dc1.example.com:389"}, want: false},
+		{name: "4", config: Config{serverURL: "ldap://dc1.example.com:389", startTLS: true}, want: true},
+	}
+	for _, tt := range tests {
+		if got := tt.config.isConfidential(); got != tt.want {
+			t.Errorf("Config.isConfidential() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_isPasswordVersionDrifted(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracked string
+		lastSet string
+		want    bool
+	}{
+		{name: "1", tracked: "", lastSet: "", want: false},
+		{name: "2", tracked: "", lastSet: "2021-01-01T00:00:00Z", want: false},
+		{name: "3", tracked: "2021-01-01T00:00:00Z", lastSet: "2021-01-01T00:00:00Z", want: false},
+		{name: "4", tracked: "2021-01-01T00:00:00Z", lastSet: "2021-02-01T00:00:00Z", want: true},
+		// password reset with 'must change password' outside of terraform
+		{name: "5", tracked: "2021-01-01T00:00:00Z", lastSet: "", want: true},
+	}
+	for _, tt := range tests {
+		if got := isPasswordVersionDrifted(tt.tracked, tt.lastSet); got != tt.want {
+			t.Errorf("isPasswordVersionDrifted() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_validatePassword(t *testing.T) {
+	domain := &passwordPolicy{dn: "DC=example,DC=com", minLength: 7, complexity: true}
+	pso := &passwordPolicy{dn: "CN=pso,CN=Password Settings Container,CN=System,DC=example,DC=com", minLength: 15, complexity: false}
+	type args struct {
+		password    string
+		policy      *passwordPolicy
+		sam         string
+		displayName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "1", args: args{"Secret#123", domain, "jdoe", "John Doe"}, wantErr: false},
+		{name: "2", args: args{"Sec#1", domain, "jdoe", "John Doe"}, wantErr: true},
+		{name: "3", args: args{"secretpassword", domain, "jdoe", "John Doe"}, wantErr: true},
+		{name: "4", args: args{"secretpassword1", domain, "jdoe", "John Doe"}, wantErr: true},
+		{name: "5", args: args{"secretpassword1#", domain, "jdoe", "John Doe"}, wantErr: false},
+		{name: "6", args: args{"Secret#JDoe1", domain, "jdoe", ""}, wantErr: true},
+		{name: "7", args: args{"Secret#john1", domain, "jd", "John Doe"}, wantErr: true},
+		// letters which are neither uppercase nor lowercase are separate category
+		{name: "8", args: args{"secret1日本", domain, "jdoe", "John Doe"}, wantErr: false},
+		{name: "9", args: args{"secretpassword", pso, "jdoe", "John Doe"}, wantErr: true},
+		{name: "10", args: args{"johndoepassword", pso, "jdoe", "John Doe"}, wantErr: false},
+	}
+	for _, tt := range tests {
+		err := validatePassword(tt.args.password, tt.args.policy, tt.args.sam, tt.args.displayName)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validatePassword() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_countryAttributeValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{name: "1", code: "US", want: map[string][]string{"c": {"US"}, "co": {"United States"}, "countryCode": {"840"}}, wantErr: false},
+		{name: "2", code: "gb", want: map[string][]string{"c": {"GB"}, "co": {"United Kingdom"}, "countryCode": {"826"}}, wantErr: false},
+		{name: "3", code: "AF", want: map[string][]string{"c": {"AF"}, "co": {"Afghanistan"}, "countryCode": {"4"}}, wantErr: false},
+		{name: "4", code: "", want: map[string][]string{"c": {}, "co": {}, "countryCode": {"0"}}, wantErr: false},
+		{name: "5", code: "XX", want: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := countryAttributeValues(tt.code)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("countryAttributeValues() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("countryAttributeValues() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_isAttributeInJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes string
+		attr       string
+		want       bool
+	}{
+		{name: "1", attributes: `{"mail":["user@example.com"]}`, attr: "mail", want: true},
+		{name: "2", attributes: `{"Mail":["user@example.com"]}`, attr: "mail", want: true},
+		{name: "3", attributes: `{"department":["IT"]}`, attr: "mail", want: false},
+		{name: "4", attributes: `{}`, attr: "mail", want: false},
+		{name: "5", attributes: ``, attr: "mail", want: false},
+	}
+	for _, tt := range tests {
+		if got := isAttributeInJSON(tt.attributes, tt.attr); got != tt.want {
+			t.Errorf("isAttributeInJSON() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_validateDNOrGUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "CN=manager1,OU=Users,DC=example,DC=com", wantErr: false},
+		{name: "2", value: "b9edea5d-2f94-4d78-87b8-6b75af7017d6", wantErr: false},
+		{name: "3", value: "manager1", wantErr: true},
+		{name: "4", value: "S-1-5-32-544", wantErr: true},
+		{name: "5", value: "CN=manager1,,DC=com", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateDNOrGUID(tt.value, "manager")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateDNOrGUID() name = %s errs = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_validateUNCPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: `\\server\share`, wantErr: false},
+		{name: "2", value: `\\server.example.com\home$\user1`, wantErr: false},
+		{name: "3", value: `\\server\share\folder\`, wantErr: false},
+		{name: "4", value: `\\server`, wantErr: true},
+		{name: "5", value: `C:\home\user1`, wantErr: true},
+		{name: "6", value: `//server/share`, wantErr: true},
+		{name: "7", value: `\\server\share\fol*der`, wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateUNCPath(tt.value, "home_directory")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateUNCPath() name = %s errs = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_validateScriptPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: `logon.bat`, wantErr: false},
+		{name: "2", value: `scripts\logon.bat`, wantErr: false},
+		{name: "3", value: `\\server\netlogon\logon.bat`, wantErr: false},
+		{name: "4", value: `C:\scripts\logon.bat`, wantErr: true},
+		{name: "5", value: `\scripts\logon.bat`, wantErr: true},
+		{name: "6", value: `\\server`, wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateScriptPath(tt.value, "script_path")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateScriptPath() name = %s errs = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_validateWorkstationName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "WKS001", wantErr: false},
+		{name: "2", value: "VDI-POOL-000001", wantErr: false},
+		{name: "3", value: "VDI-POOL-0000001", wantErr: true},
+		{name: "4", value: "WKS001,WKS002", wantErr: true},
+		{name: "5", value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateWorkstationName(tt.value, "user_workstations")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateWorkstationName() name = %s errs = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_logonHours(t *testing.T) {
+	workdays := []logonHoursRange{
+		{day: 1, start: 8, end: 18},
+		{day: 2, start: 8, end: 18},
+		{day: 3, start: 8, end: 18},
+		{day: 4, start: 8, end: 18},
+		{day: 5, start: 8, end: 18},
+	}
+	tests := []struct {
+		name   string
+		ranges []logonHoursRange
+		offset int
+		want   string
+	}{
+		{name: "1", ranges: workdays, offset: 0, want: "00000000ff0300ff0300ff0300ff0300ff03000000"},
+		{name: "2", ranges: workdays, offset: 2, want: "000000c0ff00c0ff00c0ff00c0ff00c0ff00000000"},
+		// monday 00:00-02:00 at +02:00 is sunday 22:00-24:00 UTC
+		{name: "3", ranges: []logonHoursRange{{day: 1, start: 0, end: 2}}, offset: 2, want: "0000c0000000000000000000000000000000000000"},
+		// saturday 22:00-24:00 at -05:00 wraps to sunday 03:00-05:00 UTC
+		{name: "4", ranges: []logonHoursRange{{day: 6, start: 22, end: 24}}, offset: -5, want: "180000000000000000000000000000000000000000"},
+		{name: "5", ranges: nil, offset: 0, want: "000000000000000000000000000000000000000000"},
+	}
+	for _, tt := range tests {
+		got := encodeLogonHours(tt.ranges, tt.offset)
+		if hex.EncodeToString(got) != tt.want {
+			t.Errorf("encodeLogonHours() name = %s Got = %x, want %v", tt.name, got, tt.want)
+		}
+		decoded, err := decodeLogonHours(got, tt.offset)
+		if err != nil {
+			t.Errorf("decodeLogonHours() name = %s error = %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, tt.ranges) {
+			t.Errorf("decodeLogonHours() name = %s Got = %v, want %v", tt.name, decoded, tt.ranges)
+		}
+	}
+
+	if _, err := decodeLogonHours([]byte{0xFF}, 0); err == nil {
+		t.Errorf("decodeLogonHours() invalid length error expected")
+	}
+	all := make([]byte, logonHoursLength)
+	for i := range all {
+		all[i] = 0xFF
+	}
+	if !isAllLogonHours(all) || isAllLogonHours(encodeLogonHours(workdays, 0)) {
+		t.Errorf("isAllLogonHours() unexpected result")
+	}
+}
+
+func Test_logonHoursOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		tz      string
+		want    int
+		wantErr bool
+	}{
+		{name: "1", tz: "UTC", want: 0, wantErr: false},
+		{name: "2", tz: "+02:00", want: 2, wantErr: false},
+		{name: "3", tz: "-05:00", want: -5, wantErr: false},
+		{name: "4", tz: "+05:30", want: 0, wantErr: true},
+		{name: "5", tz: "Not/AZone", want: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := logonHoursOffset(tt.tz)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("logonHoursOffset() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("logonHoursOffset() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_validateLogonHoursRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranges  []logonHoursRange
+		wantErr bool
+	}{
+		{name: "1", ranges: []logonHoursRange{{1, 8, 12}, {1, 13, 18}, {2, 8, 18}}, wantErr: false},
+		{name: "2", ranges: []logonHoursRange{{1, 8, 12}, {1, 12, 18}}, wantErr: true},
+		{name: "3", ranges: []logonHoursRange{{1, 8, 12}, {1, 10, 18}}, wantErr: true},
+		{name: "4", ranges: []logonHoursRange{{1, 18, 8}}, wantErr: true},
+		// full week is read from AD as no logon_hours block
+		{name: "5", ranges: []logonHoursRange{{0, 0, 24}, {1, 0, 24}, {2, 0, 24}, {3, 0, 24}, {4, 0, 24}, {5, 0, 24}, {6, 0, 24}}, wantErr: true},
+		{name: "6", ranges: []logonHoursRange{{0, 0, 24}, {1, 0, 24}, {2, 0, 24}, {3, 0, 24}, {4, 0, 24}, {5, 0, 24}, {6, 0, 23}}, wantErr: false},
+	}
+	for _, tt := range tests {
+		if err := validateLogonHoursRanges(tt.ranges); (err != nil) != tt.wantErr {
+			t.Errorf("validateLogonHoursRanges() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_generatePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		length     int
+		complexity bool
+		wantLength int
+	}{
+		{name: "1", length: 24, complexity: true, wantLength: 24},
+		{name: "2", length: 64, complexity: true, wantLength: 64},
+		{name: "3", length: 2, complexity: true, wantLength: 4},
+		{name: "4", length: 16, complexity: false, wantLength: 16},
+	}
+	for _, tt := range tests {
+		got, err := generatePassword(tt.length, tt.complexity, "user1", "John Doe")
+		if err != nil {
+			t.Errorf("generatePassword() name = %s error = %v", tt.name, err)
+			continue
+		}
+		if len(got) != tt.wantLength {
+			t.Errorf("generatePassword() name = %s Got length = %d, want %d", tt.name, len(got), tt.wantLength)
+		}
+		if !tt.complexity {
+			continue
+		}
+		for _, set := range []string{passwordLowerChars, passwordUpperChars, passwordDigitChars, passwordSpecialChars} {
+			if !strings.ContainsAny(got, set) {
+				t.Errorf("generatePassword() name = %s password doesn't contain any of %s", tt.name, set)
+			}
+		}
+	}
+}
+
+func Test_containsAccountName(t *testing.T) {
+	type args struct {
+		password    string
+		sam         string
+		displayName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "1", args: args{"Secret!123", "user1", "John Doe"}, want: false},
+		{name: "2", args: args{"xUSER1!123", "user1", "John Doe"}, want: true},
+		{name: "3", args: args{"Johnny!123", "user1", "John Doe"}, want: true},
+		{name: "4", args: args{"Secret!doe", "user1", "Erin M. Hagens-Doe"}, want: true},
+		{name: "5", args: args{"Secret!M12", "user1", "Erin M. Hagens"}, want: false},
+		{name: "6", args: args{"Secret!ab", "ab", ""}, want: false},
+	}
+	for _, tt := range tests {
+		if got := containsAccountName(tt.args.password, tt.args.sam, tt.args.displayName); got != tt.want {
+			t.Errorf("containsAccountName() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_encodeEncryptionTypes(t *testing.T) {
+	type args struct {
+		types   []string
+		current string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{name: "1", args: args{[]string{"RC4", "AES128", "AES256"}, ""}, want: "28", wantErr: false},
+		{name: "2", args: args{[]string{}, ""}, want: "0", wantErr: false},
+		// FAST and compound identity bits are preserved
+		{name: "3", args: args{[]string{"AES256"}, "100"}, want: "112", wantErr: false},
+		{name: "4", args: args{[]string{"DES_CBC_CRC", "DES_CBC_MD5"}, "28"}, want: "3", wantErr: false},
+		{name: "5", args: args{[]string{"AES512"}, ""}, want: "", wantErr: true},
+		{name: "6", args: args{[]string{"RC4"}, "abc"}, want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := encodeEncryptionTypes(tt.args.types, tt.args.current)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("encodeEncryptionTypes() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("encodeEncryptionTypes() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_decodeEncryptionTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "1", value: "", want: []string{}, wantErr: false},
+		{name: "2", value: "28", want: []string{"AES128", "AES256", "RC4"}, wantErr: false},
+		{name: "3", value: "112", want: []string{"AES256"}, wantErr: false},
+		{name: "4", value: "0", want: []string{}, wantErr: false},
+		{name: "5", value: "abc", want: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := decodeEncryptionTypes(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("decodeEncryptionTypes() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("decodeEncryptionTypes() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_validateMSASAMAccountName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "svc_app$", wantErr: false},
+		{name: "2", value: "svc_app", wantErr: true},
+		{name: "3", value: "svc_application1$", wantErr: true},
+		{name: "4", value: "svc_application$", wantErr: false},
+	}
+	for _, tt := range tests {
+		_, errs := validateMSASAMAccountName(tt.value, "sam_account_name")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateMSASAMAccountName() name = %s errors = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_validateDNOrSID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "CN=servers,OU=groups,DC=example,DC=com", wantErr: false},
+		{name: "2", value: "S-1-5-21-1004336348-1177238915-682003330-1104", wantErr: false},
+		{name: "3", value: "servers", wantErr: true},
+		{name: "4", value: "d4a1fe4f-2a0b-4e5a-8f4f-2f1c2b3a4d5e", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateDNOrSID(tt.value, "principals_allowed_to_retrieve_password")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateDNOrSID() name = %s errors = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_flattenPrincipals(t *testing.T) {
+	type args struct {
+		sids       []string
+		configured map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{name: "1", args: args{nil, map[string]string{}}, want: nil},
+		{
+			name: "2",
+			args: args{
+				[]string{"S-1-5-21-1-2-3-1104", "S-1-5-21-1-2-3-515"},
+				map[string]string{"CN=servers,DC=example,DC=com": "S-1-5-21-1-2-3-1104", "S-1-5-21-1-2-3-515": "S-1-5-21-1-2-3-515"},
+			},
+			want: []string{"CN=servers,DC=example,DC=com", "S-1-5-21-1-2-3-515"},
+		},
+		// configured principal removed outside of terraform and unknown principal added
+		{
+			name: "3",
+			args: args{
+				[]string{"S-1-5-21-1-2-3-1105"},
+				map[string]string{"CN=servers,DC=example,DC=com": "S-1-5-21-1-2-3-1104"},
+			},
+			want: []string{"S-1-5-21-1-2-3-1105"},
+		},
+	}
+	for _, tt := range tests {
+		if got := flattenPrincipals(tt.args.sids, tt.args.configured); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("flattenPrincipals() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_flattenObjectClasses(t *testing.T) {
+	type args struct {
+		configured []string
+		remote     []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{name: "1", args: args{[]string{"container"}, []string{"top", "container"}}, want: []string{"container"}},
+		{name: "2", args: args{[]string{"inetOrgPerson"}, []string{"top", "person", "organizationalPerson", "user", "inetOrgPerson"}}, want: []string{"inetOrgPerson"}},
+		// case of configured class is kept
+		{name: "3", args: args{[]string{"Container"}, []string{"top", "container"}}, want: []string{"Container"}},
+		// import
+		{name: "4", args: args{nil, []string{"top", "container"}}, want: []string{"container"}},
+		{name: "5", args: args{[]string{"contact"}, []string{"top", "container"}}, want: nil},
+	}
+	for _, tt := range tests {
+		if got := flattenObjectClasses(tt.args.configured, tt.args.remote); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("flattenObjectClasses() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_getRDNAttribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		dn      string
+		want    string
+		wantErr bool
+	}{
+		{name: "1", dn: "CN=Apps,CN=Program Data,DC=example,DC=com", want: "cn"},
+		{name: "2", dn: "OU=Users,DC=example,DC=com", want: "ou"},
+		{name: "3", dn: "not a dn", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := getRDNAttribute(tt.dn)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("getRDNAttribute() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("getRDNAttribute() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_validateDNString(t *testing.T) {
+	c := &ADClient{config: Config{topDN: "dc=example,dc=com"}}
+	tests := []struct {
+		name    string
+		ou      string
+		wantErr bool
+	}{
+		{name: "1", ou: "OU=Users,DC=example,DC=com", wantErr: false},
+		// container as parent
+		{name: "2", ou: "CN=Apps,CN=Program Data,DC=example,DC=com", wantErr: false},
+		{name: "3", ou: "CN=Users, DC=example, DC=com", wantErr: false},
+		{name: "4", ou: "DC=example,DC=com", wantErr: false},
+		{name: "5", ou: "CN=Users,DC=otherexample,DC=com", wantErr: true},
+		{name: "6", ou: "CN=Users,DC=example", wantErr: true},
+		{name: "7", ou: "Users", wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := validateDNString(c, tt.ou); (err != nil) != tt.wantErr {
+			t.Errorf("validateDNString() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_validateMSAContainer(t *testing.T) {
+	c := &ADClient{config: Config{topDN: "ou=resources,dc=example,dc=com", domainDN: "dc=example,dc=com"}}
+	tests := []struct {
+		name    string
+		ou      string
+		wantErr bool
+	}{
+		{name: "1", ou: "CN=Managed Service Accounts,DC=example,DC=com", wantErr: false},
+		{name: "2", ou: "cn=managed service accounts, dc=example, dc=com", wantErr: false},
+		{name: "3", ou: "OU=MSA,OU=Resources,DC=example,DC=com", wantErr: false},
+		{name: "4", ou: "CN=Users,DC=example,DC=com", wantErr: true},
+		{name: "5", ou: "CN=Managed Service Accounts,DC=otherexample,DC=com", wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := validateMSAContainer(c, tt.ou); (err != nil) != tt.wantErr {
+			t.Errorf("validateMSAContainer() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_durationToInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "1", value: "30m", want: "-18000000000"},
+		{name: "2", value: "1008h", want: "-36288000000000"},
+		{name: "3", value: "never", want: "-9223372036854775808"},
+		{name: "4", value: "0s", want: "0"},
+		{name: "5", value: "-30m", wantErr: true},
+		{name: "6", value: "42d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := durationToInterval(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("durationToInterval() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("durationToInterval() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_intervalToDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "1", value: "-18000000000", want: "30m0s"},
+		{name: "2", value: "-36288000000000", want: "1008h0m0s"},
+		{name: "3", value: "-9223372036854775808", want: "never"},
+		{name: "4", value: "", want: ""},
+		{name: "5", value: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := intervalToDuration(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("intervalToDuration() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("intervalToDuration() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_durationDiffSuppressor(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{name: "1", old: "24h0m0s", new: "24h", want: true},
+		{name: "2", old: "30m0s", new: "1800s", want: true},
+		{name: "3", old: "never", new: "Never", want: true},
+		{name: "4", old: "30m0s", new: "1h", want: false},
+		{name: "5", old: "", new: "30m", want: false},
+	}
+	for _, tt := range tests {
+		if got := durationDiffSuppressor("lockout_duration", tt.old, tt.new, nil); got != tt.want {
+			t.Errorf("durationDiffSuppressor() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_validateDN(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "1", value: "CN=admins,OU=groups,DC=example,DC=com", wantErr: false},
+		{name: "2", value: "admins", wantErr: true},
+		{name: "3", value: "S-1-5-21-1004336348-1177238915-682003330-1104", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, errs := validateDN(tt.value, "applies_to")
+		if (len(errs) != 0) != tt.wantErr {
+			t.Errorf("validateDN() name = %s errors = %v, wantErr %v", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func Test_setPwdPropertiesFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		pwdProperties string
+		flag          uint64
+		set           bool
+		want          string
+		wantErr       bool
+	}{
+		{name: "1", pwdProperties: "0", flag: domainPasswordComplex, set: true, want: "1"},
+		// DOMAIN_LOCKOUT_ADMINS flag is preserved
+		{name: "2", pwdProperties: "9", flag: domainPasswordComplex, set: false, want: "8"},
+		{name: "3", pwdProperties: "1", flag: domainPasswordStoreCleartext, set: true, want: "17"},
+		{name: "4", pwdProperties: "", flag: domainPasswordComplex, set: true, want: "1"},
+		{name: "5", pwdProperties: "abc", flag: domainPasswordComplex, set: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := setPwdPropertiesFlag(tt.pwdProperties, tt.flag, tt.set)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("setPwdPropertiesFlag() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("setPwdPropertiesFlag() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_securityDescriptorLockKey(t *testing.T) {
+	tests := []struct {
+		name string
+		dn   string
+		want string
+	}{
+		{name: "1", dn: "OU=Test,DC=example,DC=com", want: "ou=test,dc=example,dc=com"},
+		{name: "2", dn: "ou=test, dc=example, dc=com", want: "ou=test,dc=example,dc=com"},
+		{name: "3", dn: "CN=a\\,b,OU=Test,DC=example,DC=com", want: "cn=a,b,ou=test,dc=example,dc=com"},
+		{name: "4", dn: "not a dn", want: "not a dn"},
+	}
+	for _, tt := range tests {
+		if got := securityDescriptorLockKey(tt.dn); got != tt.want {
+			t.Errorf("securityDescriptorLockKey() name = %s Got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -54,6 +54,12 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("AD_INSECURE_TLS", false),
 				Description: "If true, skips LDAP server SSL certificate verification (default: false).",
+			},
+			"start_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AD_START_TLS", false),
+				Description: "If true, ldap:// connection is upgraded to TLS using StartTLS extended operation (default: false).",
 			}},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -61,12 +67,23 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"activedirectory_computer":        resourceActivedirectoryComputer(),
-			"activedirectory_group":           resourceActivedirectoryGroup(),
-			"activedirectory_group_members":   resourceActivedirectoryGroupMembers(),
-			"activedirectory_object_memberof": resourceActivedirectoryObjectMemberOf(),
-			"activedirectory_ou":              resourceActivedirectoryOU(),
-			"activedirectory_user":            resourceActivedirectoryUser(),
+			"activedirectory_computer":               resourceActivedirectoryComputer(),
+			"activedirectory_contact":                resourceActivedirectoryContact(),
+			"activedirectory_container":              resourceActivedirectoryContainer(),
+			"activedirectory_domain_password_policy": resourceActivedirectoryDomainPasswordPolicy(),
+			"activedirectory_gmsa":                   resourceActivedirectoryGMSA(),
+			"activedirectory_group":                  resourceActivedirectoryGroup(),
+			"activedirectory_group_member":           resourceActivedirectoryGroupMember(),
+			"activedirectory_group_members":          resourceActivedirectoryGroupMembers(),
+			"activedirectory_object":                 resourceActivedirectoryObject(),
+			"activedirectory_object_ace":             resourceActivedirectoryObjectACE(),
+			"activedirectory_object_acl":             resourceActivedirectoryObjectACL(),
+			"activedirectory_object_memberof":        resourceActivedirectoryObjectMemberOf(),
+			"activedirectory_ou":                     resourceActivedirectoryOU(),
+			"activedirectory_ou_delegation":          resourceActivedirectoryOUDelegation(),
+			"activedirectory_password_settings":      resourceActivedirectoryPasswordSettings(),
+			"activedirectory_smsa":                   resourceActivedirectorySMSA(),
+			"activedirectory_user":                   resourceActivedirectoryUser(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -94,10 +111,12 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config: Config{
 			serverURL:   d.Get("ldap_url").(string),
 			domain:      strings.ToLower(domain),
+			domainDN:    domainDN,
 			topDN:       strings.ToLower(topDN),
 			username:    d.Get("bind_username").(string),
 			password:    d.Get("bind_password").(string),
 			insecureTLS: d.Get("insecure_tls").(bool),
+			startTLS:    d.Get("start_tls").(bool),
 		},
 	}
 	client.logger.Debug("providerConfigure: ad client initialised")
@@ -84,6 +84,68 @@ func resourceActivedirectoryComputer() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"password_never_expires": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The password of the object never expires (DONT_EXPIRE_PASSWORD flag)",
+			},
+			"password_not_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is not required to have a password (PASSWD_NOTREQD flag)",
+			},
+			"cannot_change_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object cannot change its own password, managed by deny ACEs of 'Change Password' extended right",
+			},
+			"smartcard_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The smart card is required for interactive logon (SMARTCARD_REQUIRED flag)",
+			},
+			"trusted_for_delegation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is trusted for Kerberos delegation (TRUSTED_FOR_DELEGATION flag)",
+			},
+			"trusted_to_auth_for_delegation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is trusted to authenticate for delegation (TRUSTED_TO_AUTH_FOR_DELEGATION flag)",
+			},
+			"not_delegated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The security context of the object is not delegated (NOT_DELEGATED flag)",
+			},
+			"use_des_key_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is restricted to use only DES encryption types for keys (USE_DES_KEY_ONLY flag)",
+			},
+			"dont_require_preauth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object doesn't require Kerberos pre-authentication (DONT_REQ_PREAUTH flag)",
+			},
+			"primary_group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The dn or SID of the primary group of the object, object is also added to the group",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validatePrimaryGroup,
+			},
 			"attributes": {
 				Type:         schema.TypeString,
 				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
@@ -131,6 +193,20 @@ func resourceCreateComputer(d *schema.ResourceData, meta interface{}) error {
 	}
 	c.logger.Info("resourceCreateComputer: computer added to active directory", "guid", guid)
 	d.SetId(guid)
+
+	if d.Get("cannot_change_password").(bool) {
+		if err := setCannotChangePassword(c.conn, addReq.DN, true); err != nil {
+			return fmt.Errorf("resourceCreateComputer: unable to set 'cannot_change_password' err: %w", err)
+		}
+		c.logger.Info("resourceCreateComputer: change password denied", "dn", addReq.DN)
+	}
+
+	if pg := d.Get("primary_group").(string); pg != "" {
+		if err := setPrimaryGroup(c, addReq.DN, pg); err != nil {
+			return fmt.Errorf("resourceCreateComputer: unable to set primary group err: %w", err)
+		}
+		c.logger.Info("resourceCreateComputer: primary group set", "dn", addReq.DN, "primary_group", pg)
+	}
 	return resourceReadComputer(d, meta)
 }
 
@@ -160,6 +236,22 @@ func resourceReadComputer(d *schema.ResourceData, meta interface{}) error {
 	if err := updateObjectSchema(resourceActivedirectoryComputer().Schema, e, d); err != nil {
 		return err
 	}
+
+	ccp, err := getCannotChangePassword(c.conn, e.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadComputer: unable to read 'cannot_change_password' err:%w", err)
+	}
+	if err := d.Set("cannot_change_password", ccp); err != nil {
+		return fmt.Errorf("resourceReadComputer: unable to update 'cannot_change_password' argument value:%v err:%w", ccp, err)
+	}
+
+	pg, err := getPrimaryGroup(c, e, d.Get("primary_group").(string))
+	if err != nil {
+		return fmt.Errorf("resourceReadComputer: unable to get primary group err:%w", err)
+	}
+	if err := d.Set("primary_group", pg); err != nil {
+		return fmt.Errorf("resourceReadComputer: unable to update 'primary_group' argument value:%v err:%w", pg, err)
+	}
 	return nil
 }
 
@@ -197,9 +289,9 @@ func resourceUpdateComputer(d *schema.ResourceData, meta interface{}) error {
 	modReq := &ldap.ModifyRequest{DN: "cn=" + d.Get("name").(string) + "," + d.Get("base_ou_dn").(string)}
 
 	// check for other arguments and attributes changes
+	uac := d.Get("user_account_control").(string)
 	if d.HasChange("enabled") {
 		enabled := d.Get("enabled").(bool)
-		uac := d.Get("user_account_control").(string)
 		if enabled {
 			uac, err = unsetaccountDisabledFlag(uac)
 			if err != nil {
@@ -211,6 +303,12 @@ func resourceUpdateComputer(d *schema.ResourceData, meta interface{}) error {
 				return fmt.Errorf("resourceUpdateComputer: unable to set account Disabled Flag for  userAccountControl value:%v ,err:%w", uac, err)
 			}
 		}
+	}
+	uac, err = setUACFlagArguments(d, uac, true)
+	if err != nil {
+		return fmt.Errorf("resourceUpdateComputer: unable to set userAccountControl flags of typed arguments value:%v ,err:%w", uac, err)
+	}
+	if uac != d.Get("user_account_control").(string) {
 		modReq.Replace("userAccountControl", []string{uac})
 		c.logger.Debug("resourceUpdateComputer: updating 'userAccountControl'", "new", uac)
 	}
@@ -250,6 +348,20 @@ func resourceUpdateComputer(d *schema.ResourceData, meta interface{}) error {
 		}
 		c.logger.Info("resourceUpdateComputer: modified", "dn", modReq.DN)
 	}
+
+	if d.HasChange("cannot_change_password") {
+		if err := setCannotChangePassword(c.conn, modReq.DN, d.Get("cannot_change_password").(bool)); err != nil {
+			return fmt.Errorf("resourceUpdateComputer: unable to update 'cannot_change_password' err: %w", err)
+		}
+		c.logger.Info("resourceUpdateComputer: 'cannot_change_password' updated", "dn", modReq.DN, "new", d.Get("cannot_change_password").(bool))
+	}
+
+	if d.HasChange("primary_group") && d.Get("primary_group").(string) != "" {
+		if err := setPrimaryGroup(c, modReq.DN, d.Get("primary_group").(string)); err != nil {
+			return fmt.Errorf("resourceUpdateComputer: unable to set primary group err: %w", err)
+		}
+		c.logger.Info("resourceUpdateComputer: primary group updated", "dn", modReq.DN, "primary_group", d.Get("primary_group").(string))
+	}
 	return resourceReadComputer(d, meta)
 }
 
@@ -292,6 +404,10 @@ func computerSchemaToAddRequest(d *schema.ResourceData) (*ldap.AddRequest, error
 			return nil, fmt.Errorf("unable to unsetaccountDisabledFlag for userAccountControl value:%v ,err:%w", uac, err)
 		}
 	}
+	uac, err = setUACFlagArguments(d, uac, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set userAccountControl flags of typed arguments value:%v ,err:%w", uac, err)
+	}
 
 	addReq.Attribute("sAMAccountName", []string{d.Get("sam_account_name").(string)})
 	addReq.Attribute("userAccountControl", []string{uac})
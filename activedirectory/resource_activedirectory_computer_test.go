@@ -131,6 +131,55 @@ func TestAccComputer_Advanced(t *testing.T) {
 	})
 }
 
+func TestAccComputer_PrimaryGroup(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputerDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with primary group set by dn
+				Config: testAccResourceADComputerPrimaryGroupTestData(baseOU, "activedirectory_group.test_acc_group_pg1.dn"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_computer.test_acc_comp3"),
+					resource.TestCheckResourceAttr("activedirectory_computer.test_acc_comp3", "primary_group", "CN=test_acc_group_pg1,"+baseOU),
+				),
+			}, {
+				// change primary group using SID
+				Config: testAccResourceADComputerPrimaryGroupTestData(baseOU, "activedirectory_group.test_acc_group_pg2.sid"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_computer.test_acc_comp3"),
+					resource.TestCheckResourceAttrPair("activedirectory_computer.test_acc_comp3", "primary_group", "activedirectory_group.test_acc_group_pg2", "sid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADComputerPrimaryGroupTestData(baseOU, primaryGroup string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_group" "test_acc_group_pg1" {
+	name             = "test_acc_group_pg1"
+	sam_account_name = "test_acc_group_pg1"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_pg2" {
+	name             = "test_acc_group_pg2"
+	sam_account_name = "test_acc_group_pg2"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_computer" "test_acc_comp3" {
+	name             = "test_acc_comp3"
+	sam_account_name = "test_acc_comp3$"
+	base_ou_dn       = "%s"
+	primary_group    = %s
+}
+`, baseOU, baseOU, baseOU, primaryGroup)
+}
+
 func testAccResourceADComputerTestData(num, enabled, name, sam, ou, description, attributes, baseOU string) string {
 	return fmt.Sprintf(`
 resource "activedirectory_computer" "test_acc_comp%s" {
@@ -0,0 +1,294 @@
+package activedirectory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceActivedirectoryContact() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the Object",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"base_ou_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The full path of the Organizational Unit (OU) or container where the object is created",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"first_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A firstname/givenname of the contact object",
+			},
+			"last_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A lastname/sn of the contact object",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The display name of the contact",
+			},
+			"initials": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The initials of the contact, max 6 characters",
+				ValidateFunc: validation.StringLenBetween(0, 6),
+			},
+			"mail": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The email address of the contact",
+				ValidateFunc: validation.StringMatch(upnStringRegexp, "mail should be in format 'someone@example.com'"),
+			},
+			"telephone_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The primary telephone number of the contact",
+			},
+			"mobile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The mobile phone number of the contact",
+			},
+			"company": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The company of the contact",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The job title of the contact",
+			},
+			"department": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The department of the contact",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the AD object",
+			},
+			"cn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Common-Name property of the object",
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the object",
+			},
+			"member_of": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The memberOf attribute of the AD object. contains object's DN.",
+				Set:         lowercaseHashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"attributes": {
+				Type:         schema.TypeString,
+				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
+				Optional:     true,
+				ValidateFunc: validateAttributesJSON,
+				StateFunc:    normalizeAttributesJSON,
+				Default:      "{}",
+			},
+		},
+		Create:        resourceCreateContact,
+		Read:          resourceReadContact,
+		Update:        resourceUpdateContact,
+		Delete:        resourceDeleteObject,
+		CustomizeDiff: resourceCustomizeDiffContact,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCreateContact(d *schema.ResourceData, meta interface{}) error {
+	var err error
+	c := meta.(*ADClient)
+	err = c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateContact: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	if err := validateDNString(c, d.Get("base_ou_dn").(string)); err != nil {
+		return fmt.Errorf("resourceCreateContact: base_ou_dn is not valid err: %w", err)
+	}
+
+	addReq := contactSchemaToAddRequest(d)
+	c.logger.Debug("resourceCreateContact: ldap add request", "addReq", addReq)
+
+	guid, err := addObject(c.conn, addReq)
+	if err != nil {
+		return fmt.Errorf("resourceCreateContact: unable to create contact err: %w", err)
+	}
+	c.logger.Info("resourceCreateContact: contact added to active directory", "guid", guid)
+	d.SetId(guid)
+	return resourceReadContact(d, meta)
+}
+
+func resourceReadContact(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadContact: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadContact: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadContact: object not found", "GUID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadContact: unable to search contact with ID  GUID:%v err:%w", d.Id(), err)
+	}
+	c.logger.Info("resourceReadContact: contact object found", "dn", e.DN)
+
+	return updateObjectSchema(resourceActivedirectoryContact().Schema, e, d)
+}
+
+func resourceUpdateContact(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdateContact: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	// check if DN is changed
+	if d.HasChanges("name", "base_ou_dn") {
+		oldName, newName := d.GetChange("name")
+		oldOU, newOU := d.GetChange("base_ou_dn")
+		if err := validateDNString(c, newOU.(string)); err != nil {
+			return fmt.Errorf("resourceUpdateContact: new base_ou_dn is not valid err: %w", err)
+		}
+		if err := renameObject(c, "cn", oldName.(string), oldOU.(string), newName.(string), newOU.(string)); err != nil {
+			return fmt.Errorf("resourceUpdateContact: %w", err)
+		}
+	}
+
+	modReq := &ldap.ModifyRequest{DN: "cn=" + d.Get("name").(string) + "," + d.Get("base_ou_dn").(string)}
+
+	if d.HasChange("first_name") {
+		if d.Get("first_name").(string) == "" {
+			modReq.Replace("givenName", []string{})
+		} else {
+			modReq.Replace("givenName", []string{d.Get("first_name").(string)})
+		}
+		c.logger.Debug("resourceUpdateContact: updating 'first_name'", "new", d.Get("first_name").(string))
+	}
+	if d.HasChange("last_name") {
+		if d.Get("last_name").(string) == "" {
+			modReq.Replace("sn", []string{})
+		} else {
+			modReq.Replace("sn", []string{d.Get("last_name").(string)})
+		}
+		c.logger.Debug("resourceUpdateContact: updating 'last_name'", "new", d.Get("last_name").(string))
+	}
+	for arg, attr := range contactStringAttributes {
+		if !d.HasChange(arg) {
+			continue
+		}
+		if d.Get(arg).(string) == "" {
+			modReq.Replace(attr, []string{})
+		} else {
+			modReq.Replace(attr, []string{d.Get(arg).(string)})
+		}
+		c.logger.Debug("resourceUpdateContact: updating '"+arg+"'", "new", d.Get(arg).(string))
+	}
+
+	if d.HasChange("description") {
+		if d.Get("description").(string) == "" {
+			modReq.Replace("description", []string{})
+		} else {
+			modReq.Replace("description", []string{d.Get("description").(string)})
+		}
+		c.logger.Debug("resourceUpdateContact: updating 'description'", "new", d.Get("description").(string))
+	}
+
+	addAttributesToModifyRequest(c, d, modReq)
+
+	if len(modReq.Changes) > 0 {
+		if err = c.conn.Modify(modReq); err != nil {
+			return fmt.Errorf("resourceUpdateContact: unable to update some attributes of LDAP object: ModifyRequest:%#v err:%w", modReq, err)
+		}
+		c.logger.Info("resourceUpdateContact: modified", "dn", modReq.DN)
+	}
+	return resourceReadContact(d, meta)
+}
+
+// resourceCustomizeDiffContact makes sure typed arguments are not also set in 'attributes'.
+func resourceCustomizeDiffContact(d *schema.ResourceDiff, meta interface{}) error {
+	attributes := d.Get("attributes").(string)
+	for arg, attr := range contactStringAttributes {
+		if d.Get(arg).(string) != "" && isAttributeInJSON(attributes, attr) {
+			return fmt.Errorf("resourceCustomizeDiffContact: attribute '%s' is managed by '%s' argument and should not be set in 'attributes'", attr, arg)
+		}
+	}
+	return nil
+}
+
+func contactSchemaToAddRequest(d *schema.ResourceData) *ldap.AddRequest {
+	var addReq ldap.AddRequest
+
+	name := d.Get("name").(string)
+	attributes := d.Get("attributes").(string)
+
+	addReq.DN = "cn=" + name + "," + d.Get("base_ou_dn").(string)
+
+	// add attributes
+	attrMap := map[string][]string{}
+	_ = json.Unmarshal([]byte(attributes), &attrMap)
+	for name, values := range attrMap {
+		addReq.Attribute(name, values)
+	}
+
+	addReq.Attribute("objectClass", []string{"contact"})
+	addReq.Attribute("name", []string{name})
+	addReq.Attribute("cn", []string{name})
+	if d.Get("first_name").(string) != "" {
+		addReq.Attribute("givenName", []string{d.Get("first_name").(string)})
+	}
+	if d.Get("last_name").(string) != "" {
+		addReq.Attribute("sn", []string{d.Get("last_name").(string)})
+	}
+	if d.Get("description").(string) != "" {
+		addReq.Attribute("description", []string{d.Get("description").(string)})
+	}
+	for arg, attr := range contactStringAttributes {
+		if v := d.Get(arg).(string); v != "" {
+			addReq.Attribute(attr, []string{v})
+		}
+	}
+
+	return &addReq
+}
@@ -0,0 +1,165 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("activedirectory_contact", &resource.Sweeper{
+		Name: "activedirectory_contact",
+		F: func(r string) error {
+			client, err := sharedClient()
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			c := client.(*ADClient)
+
+			err = c.initialiseConn()
+			if err != nil {
+				return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+			}
+			defer c.done()
+
+			// contact has no sAMAccountName, it's searched by cn
+			sReq := &ldap.SearchRequest{
+				BaseDN:       c.config.topDN,
+				Scope:        ldap.ScopeWholeSubtree,
+				DerefAliases: ldap.NeverDerefAliases,
+				SizeLimit:    0,
+				TimeLimit:    0,
+				TypesOnly:    false,
+				Filter:       "(&(objectClass=contact)(cn=test_acc_contact*))",
+				Attributes:   []string{"*"},
+				Controls:     nil,
+			}
+
+			sr, err := c.conn.Search(sReq)
+			if err != nil {
+				if ldap.IsErrorWithCode(err, 32) {
+					return nil
+				}
+				return err
+			}
+			var unDeleted []string
+			for _, e := range sr.Entries {
+				c.logger.Info("Sweep test deleting object...", "DN", e.DN)
+				request := ldap.DelRequest{DN: e.DN}
+				err = c.conn.Del(&request)
+				if err != nil {
+					c.logger.Error("unable to delete object", "DN", e.DN, "err", err)
+					unDeleted = append(unDeleted, e.DN)
+				}
+			}
+			if len(unDeleted) != 0 {
+				return fmt.Errorf("unable to delete object, DNs: %s", unDeleted)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccContact_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContactDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with only required argument defined
+				Config: fmt.Sprintf(`resource "activedirectory_contact" "test_acc_contact1" {
+					name       = "test_acc_contact1"
+					base_ou_dn = "%s"
+				}`, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_contact.test_acc_contact1"),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact1", "name", "test_acc_contact1"),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact1", "dn", "CN=test_acc_contact1,"+baseOU),
+				),
+			}, {
+				ResourceName:      "activedirectory_contact.test_acc_contact1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccContact_Advanced(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContactDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create contact with typed arguments and add it to a group
+				Config: testAccResourceADContactTestData("test_acc_contact2", "Sales", baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_contact.test_acc_contact2"),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact2", "mail", "test_acc_contact2@example.com"),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact2", "company", "example"),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact2", "department", "Sales"),
+					testAccCheckGroupMemberPairRemote("activedirectory_group_member.test_acc_group_contact"),
+				),
+			}, {
+				// rename contact and update argument
+				Config: testAccResourceADContactTestData("test_acc_contact3", "Marketing", baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_contact.test_acc_contact2"),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact2", "dn", "CN=test_acc_contact3,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_contact.test_acc_contact2", "department", "Marketing"),
+					testAccCheckGroupMemberPairRemote("activedirectory_group_member.test_acc_group_contact"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADContactTestData(name, department, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_contact" "test_acc_contact2" {
+	name             = "%s"
+	base_ou_dn       = "%s"
+	first_name       = "first"
+	last_name        = "last"
+	display_name     = "first last"
+	mail             = "test_acc_contact2@example.com"
+	telephone_number = "+1 555 0100"
+	company          = "example"
+	department       = "%s"
+	attributes       = jsonencode({
+		l = ["London"]
+	})
+}
+
+resource "activedirectory_group" "test_acc_group_contact" {
+	name             = "test_acc_group_contact"
+	sam_account_name = "test_acc_group_contact"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group_member" "test_acc_group_contact" {
+	group_dn  = activedirectory_group.test_acc_group_contact.dn
+	member_dn = activedirectory_contact.test_acc_contact2.dn
+}
+`, name, baseOU, department, baseOU)
+}
+
+func testAccCheckContactDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "activedirectory_contact" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package activedirectory
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// resourceActivedirectoryContainer is a generic object of 'container' class with typed 'description' argument,
+// CRUD is shared with object resource.
+func resourceActivedirectoryContainer() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the Object",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"base_ou_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The full path of the Organizational Unit (OU) or container where the object is created",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the AD object",
+			},
+			"cn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Common-Name property of the object",
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the object",
+			},
+			"attributes": {
+				Type:         schema.TypeString,
+				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
+				Optional:     true,
+				ValidateFunc: validateAttributesJSON,
+				StateFunc:    normalizeAttributesJSON,
+				Default:      "{}",
+			},
+		},
+		Create: resourceCreateContainer,
+		Read:   resourceReadContainer,
+		Update: resourceUpdateContainer,
+		Delete: resourceDeleteObject,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCreateContainer(d *schema.ResourceData, meta interface{}) error {
+	return createObject(d, meta, resourceActivedirectoryContainer().Schema, "cn", []string{"container"})
+}
+
+func resourceReadContainer(d *schema.ResourceData, meta interface{}) error {
+	return readObject(d, meta, resourceActivedirectoryContainer().Schema)
+}
+
+func resourceUpdateContainer(d *schema.ResourceData, meta interface{}) error {
+	return updateObject(d, meta, resourceActivedirectoryContainer().Schema, "cn")
+}
@@ -0,0 +1,196 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("activedirectory_container", &resource.Sweeper{
+		Name: "activedirectory_container",
+		F: func(r string) error {
+			client, err := sharedClient()
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			c := client.(*ADClient)
+
+			err = c.initialiseConn()
+			if err != nil {
+				return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+			}
+			defer c.done()
+
+			sReq := &ldap.SearchRequest{
+				BaseDN:       c.config.topDN,
+				Scope:        ldap.ScopeWholeSubtree,
+				DerefAliases: ldap.NeverDerefAliases,
+				SizeLimit:    0,
+				TimeLimit:    0,
+				TypesOnly:    false,
+				Filter:       "(&(objectClass=container)(cn=test_acc_container*))",
+				Attributes:   []string{"*"},
+				Controls:     nil,
+			}
+
+			sr, err := c.conn.Search(sReq)
+			if err != nil {
+				if ldap.IsErrorWithCode(err, 32) {
+					return nil
+				}
+				return err
+			}
+			var unDeleted []string
+			for _, e := range sr.Entries {
+				c.logger.Info("Sweep test deleting object...", "DN", e.DN)
+				request := ldap.DelRequest{DN: e.DN}
+				err = c.conn.Del(&request)
+				if err != nil {
+					c.logger.Error("unable to delete object", "DN", e.DN, "err", err)
+					unDeleted = append(unDeleted, e.DN)
+				}
+			}
+			if len(unDeleted) != 0 {
+				return fmt.Errorf("unable to delete object, DNs: %s", unDeleted)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccContainer_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with only required argument defined
+				Config: fmt.Sprintf(`resource "activedirectory_container" "test_acc_container1" {
+					name             = "test_acc_container1"
+					base_ou_dn       = "%s"
+				}`, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_container.test_acc_container1"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container1", "name", "test_acc_container1"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container1", "base_ou_dn", baseOU),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container1", "dn", "CN=test_acc_container1,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container1", "attributes", "{}"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container1", "description", ""),
+				),
+			}, {
+				ResourceName:      "activedirectory_container.test_acc_container1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccContainer_Advanced(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create container with optional arguments defined
+				Config: testAccResourceADContainerTestData("test_acc_container2", baseOU, "testing description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_container.test_acc_container2"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container2", "description", "testing description"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container2", "dn", "CN=test_acc_container2,"+baseOU),
+				),
+			}, {
+				// change name and description
+				Config: testAccResourceADContainerTestData("test_acc_container2_new", baseOU, "testing description update"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_container.test_acc_container2"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container2", "name", "test_acc_container2_new"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container2", "description", "testing description update"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container2", "dn", "CN=test_acc_container2_new,"+baseOU),
+				),
+			}, {
+				// move container under another container
+				Config: fmt.Sprintf(`resource "activedirectory_container" "test_acc_container2" {
+					name             = "test_acc_container2_new"
+					base_ou_dn       = activedirectory_container.test_acc_container3.dn
+					description      = "testing description update"
+				}
+
+				resource "activedirectory_container" "test_acc_container3" {
+					name             = "test_acc_container3"
+					base_ou_dn       = "%s"
+				}`, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_container.test_acc_container2"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container2", "dn", "CN=test_acc_container2_new,CN=test_acc_container3,"+baseOU),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContainer_Parent(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				// container is used as base_ou_dn of other objects
+				Config: fmt.Sprintf(`resource "activedirectory_container" "test_acc_container4" {
+					name             = "test_acc_container4"
+					base_ou_dn       = "%s"
+				}
+
+				resource "activedirectory_container" "test_acc_container_child" {
+					name             = "test_acc_container_child"
+					base_ou_dn       = activedirectory_container.test_acc_container4.dn
+				}
+
+				resource "activedirectory_group" "test_acc_group_container" {
+					name             = "test_acc_group_container"
+					sam_account_name = "test_acc_group_container"
+					base_ou_dn       = activedirectory_container.test_acc_container4.dn
+				}`, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_container.test_acc_container_child"),
+					testAccCheckObjectRemoteAttr("activedirectory_group.test_acc_group_container"),
+					resource.TestCheckResourceAttr("activedirectory_container.test_acc_container_child", "dn", "CN=test_acc_container_child,CN=test_acc_container4,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_group.test_acc_group_container", "dn", "CN=test_acc_group_container,CN=test_acc_container4,"+baseOU),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADContainerTestData(name, ou, description string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_container" "test_acc_container2" {
+	name             = "%s"
+	base_ou_dn       = "%s"
+	description      = "%s"
+}
+`, name, ou, description)
+}
+
+func testAccCheckContainerDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "activedirectory_container" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package activedirectory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// domainPasswordPolicyIntAttributes maps integer arguments of domain password policy resource to attributes of domain object
+var domainPasswordPolicyIntAttributes = map[string]string{
+	"min_password_length":     "minPwdLength",
+	"password_history_length": "pwdHistoryLength",
+	"lockout_threshold":       "lockoutThreshold",
+}
+
+// domainPasswordPolicyDurationAttributes maps duration arguments of domain password policy resource to attributes of domain object
+var domainPasswordPolicyDurationAttributes = map[string]string{
+	"min_password_age":           "minPwdAge",
+	"max_password_age":           "maxPwdAge",
+	"lockout_duration":           "lockoutDuration",
+	"lockout_observation_window": "lockOutObservationWindow",
+}
+
+// domainPasswordPolicyFlags maps boolean arguments of domain password policy resource to flags of pwdProperties attribute
+var domainPasswordPolicyFlags = map[string]uint64{
+	"complexity_enabled":            domainPasswordComplex,
+	"reversible_encryption_enabled": domainPasswordStoreCleartext,
+}
+
+func resourceActivedirectoryDomainPasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"min_password_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The minimum length of passwords (minPwdLength)",
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+			"password_history_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The number of old passwords which can't be reused (pwdHistoryLength)",
+				ValidateFunc: validation.IntBetween(0, 1024),
+			},
+			"complexity_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The passwords must meet complexity requirements (DOMAIN_PASSWORD_COMPLEX flag of pwdProperties)",
+			},
+			"reversible_encryption_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The passwords are stored using reversible encryption (DOMAIN_PASSWORD_STORE_CLEARTEXT flag of pwdProperties)",
+			},
+			"min_password_age": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The minimum age of password before it can be changed (minPwdAge), ie '24h'",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"max_password_age": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The maximum age of password before it expires (maxPwdAge), ie '1008h' or 'never'",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"lockout_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The number of failed logon attempts which locks out the account (lockoutThreshold), 0 means account is never locked out",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"lockout_duration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The duration of account lockout (lockoutDuration), ie '30m' or 'never' for lockout until administrator unlocks the account",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"lockout_observation_window": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The duration after which failed logon attempts counter is reset (lockOutObservationWindow), ie '30m'",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the domain object",
+			},
+		},
+		Create: resourceCreateDomainPasswordPolicy,
+		Read:   resourceReadDomainPasswordPolicy,
+		Update: resourceUpdateDomainPasswordPolicy,
+		Delete: resourceDeleteDomainPasswordPolicy,
+		Importer: &schema.ResourceImporter{
+			State: resourceImportDomainPasswordPolicy,
+		},
+	}
+}
+
+func resourceCreateDomainPasswordPolicy(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateDomainPasswordPolicy: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	// policy always exists on the domain object, creating resource only applies configured arguments
+	if err := updateDomainPasswordPolicy(c, d, false); err != nil {
+		return fmt.Errorf("resourceCreateDomainPasswordPolicy: %w", err)
+	}
+	d.SetId(c.config.domainDN)
+	return resourceReadDomainPasswordPolicy(d, meta)
+}
+
+func resourceReadDomainPasswordPolicy(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	attributes := []string{"distinguishedName", "pwdProperties"}
+	for _, attr := range domainPasswordPolicyIntAttributes {
+		attributes = append(attributes, attr)
+	}
+	for _, attr := range domainPasswordPolicyDurationAttributes {
+		attributes = append(attributes, attr)
+	}
+	e, err := getObjectAttributes(c.conn, c.config.domainDN, attributes)
+	if err != nil {
+		return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to read domain object:%s err:%w", c.config.domainDN, err)
+	}
+	c.logger.Info("resourceReadDomainPasswordPolicy: domain object found", "dn", e.DN)
+
+	for arg, attr := range domainPasswordPolicyIntAttributes {
+		rv := e.GetAttributeValue(attr)
+		v, err := strconv.Atoi(rv)
+		if err != nil {
+			return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to parse '%s' value:%v err:%w", attr, rv, err)
+		}
+		if err := d.Set(arg, v); err != nil {
+			return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to update '%s' argument value:%v err:%w", arg, v, err)
+		}
+	}
+	for arg, attr := range domainPasswordPolicyDurationAttributes {
+		v, err := intervalToDuration(e.GetAttributeValue(attr))
+		if err != nil {
+			return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to convert '%s' value err:%w", attr, err)
+		}
+		if err := d.Set(arg, v); err != nil {
+			return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to update '%s' argument value:%v err:%w", arg, v, err)
+		}
+	}
+	properties, err := strconv.ParseUint(e.GetAttributeValue("pwdProperties"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to parse pwdProperties value %s to uint", e.GetAttributeValue("pwdProperties"))
+	}
+	for arg, flag := range domainPasswordPolicyFlags {
+		if err := d.Set(arg, properties&flag != 0); err != nil {
+			return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to update '%s' argument value:%v err:%w", arg, properties&flag != 0, err)
+		}
+	}
+	if err := d.Set("dn", e.GetAttributeValue("distinguishedName")); err != nil {
+		return fmt.Errorf("resourceReadDomainPasswordPolicy: unable to update 'dn' argument value:%v err:%w", e.GetAttributeValue("distinguishedName"), err)
+	}
+	return nil
+}
+
+func resourceUpdateDomainPasswordPolicy(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdateDomainPasswordPolicy: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	if err := updateDomainPasswordPolicy(c, d, true); err != nil {
+		return fmt.Errorf("resourceUpdateDomainPasswordPolicy: %w", err)
+	}
+	return resourceReadDomainPasswordPolicy(d, meta)
+}
+
+// resourceDeleteDomainPasswordPolicy only removes the resource from the state, password policy of the domain is not changed.
+func resourceDeleteDomainPasswordPolicy(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	c.logger.Info("resourceDeleteDomainPasswordPolicy: domain password policy removed from state, policy is not changed", "dn", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// resourceImportDomainPasswordPolicy imports password policy of the domain, ID should be dn of the domain.
+func resourceImportDomainPasswordPolicy(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*ADClient)
+	if !strings.EqualFold(d.Id(), c.config.domainDN) {
+		return nil, fmt.Errorf("resourceImportDomainPasswordPolicy: ID should be dn of the domain %q, got: %s", c.config.domainDN, d.Id())
+	}
+	d.SetId(c.config.domainDN)
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateDomainPasswordPolicy modifies password policy attributes of domain object.
+// if onlyChanged is true only arguments with changes are applied, otherwise arguments set in configuration are applied.
+func updateDomainPasswordPolicy(c *ADClient, d *schema.ResourceData, onlyChanged bool) error {
+	isSet := func(arg string) bool {
+		if onlyChanged {
+			return d.HasChange(arg)
+		}
+		_, ok := d.GetOkExists(arg)
+		return ok
+	}
+
+	modReq := &ldap.ModifyRequest{DN: c.config.domainDN}
+
+	for arg, attr := range domainPasswordPolicyIntAttributes {
+		if isSet(arg) {
+			modReq.Replace(attr, []string{strconv.Itoa(d.Get(arg).(int))})
+			c.logger.Debug("updateDomainPasswordPolicy: updating '"+arg+"'", "new", d.Get(arg).(int))
+		}
+	}
+	for arg, attr := range domainPasswordPolicyDurationAttributes {
+		if isSet(arg) && d.Get(arg).(string) != "" {
+			v, err := durationToInterval(d.Get(arg).(string))
+			if err != nil {
+				return fmt.Errorf("unable to convert '%s' err:%w", arg, err)
+			}
+			modReq.Replace(attr, []string{v})
+			c.logger.Debug("updateDomainPasswordPolicy: updating '"+arg+"'", "new", d.Get(arg).(string))
+		}
+	}
+
+	var changedFlags []string
+	for arg := range domainPasswordPolicyFlags {
+		if isSet(arg) {
+			changedFlags = append(changedFlags, arg)
+		}
+	}
+	if len(changedFlags) > 0 {
+		// other flags of pwdProperties are preserved
+		e, err := getObjectAttributes(c.conn, c.config.domainDN, []string{"pwdProperties"})
+		if err != nil {
+			return fmt.Errorf("unable to read domain object:%s err:%w", c.config.domainDN, err)
+		}
+		properties := e.GetAttributeValue("pwdProperties")
+		for _, arg := range changedFlags {
+			properties, err = setPwdPropertiesFlag(properties, domainPasswordPolicyFlags[arg], d.Get(arg).(bool))
+			if err != nil {
+				return err
+			}
+			c.logger.Debug("updateDomainPasswordPolicy: updating '"+arg+"'", "new", d.Get(arg).(bool))
+		}
+		modReq.Replace("pwdProperties", []string{properties})
+	}
+
+	if len(modReq.Changes) > 0 {
+		if err := c.conn.Modify(modReq); err != nil {
+			return fmt.Errorf("unable to update password policy of domain object: ModifyRequest:%#v err:%w", modReq, err)
+		}
+		c.logger.Info("updateDomainPasswordPolicy: modified", "dn", modReq.DN)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// testAccDomainPasswordPolicyAttributes are attributes of domain object changed by the acceptance test
+var testAccDomainPasswordPolicyAttributes = []string{"minPwdLength", "pwdHistoryLength", "pwdProperties", "maxPwdAge"}
+
+func TestAccDomainPasswordPolicy_Basic(t *testing.T) {
+	// current policy is needed to build configs, so it's saved before the test case
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar)
+	}
+	testAccPreCheck(t)
+	saved := testAccSaveDomainPasswordPolicy(t)
+	// domain policy is shared by the whole test domain, so it's restored even if the test fails
+	defer testAccRestoreDomainPasswordPolicy(t, saved)
+
+	minLength, err := strconv.Atoi(saved["minPwdLength"])
+	if err != nil {
+		t.Fatalf("unable to parse minPwdLength value:%s err:%v", saved["minPwdLength"], err)
+	}
+	stricterLength := minLength + 1
+	if minLength == 255 {
+		stricterLength = minLength - 1
+	}
+	complexity, err := testAccSavedComplexity(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxAge, err := intervalToDuration(saved["maxPwdAge"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckDomainPasswordPolicyDestroy(saved)
+		},
+		Steps: []resource.TestStep{
+			{
+				// configured values are current values of the domain, so the test domain policy is not changed
+				Config: testAccResourceADDomainPasswordPolicyTestData(minLength, saved["pwdHistoryLength"], complexity, maxAge),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_domain_password_policy.test_acc_policy", "min_password_length", saved["minPwdLength"]),
+					resource.TestCheckResourceAttr("activedirectory_domain_password_policy.test_acc_policy", "password_history_length", saved["pwdHistoryLength"]),
+					resource.TestCheckResourceAttr("activedirectory_domain_password_policy.test_acc_policy", "complexity_enabled", strconv.FormatBool(complexity)),
+					resource.TestCheckResourceAttr("activedirectory_domain_password_policy.test_acc_policy", "max_password_age", maxAge),
+					resource.TestCheckResourceAttrSet("activedirectory_domain_password_policy.test_acc_policy", "lockout_duration"),
+				),
+			}, {
+				// change minimum length and enforce complexity
+				Config: testAccResourceADDomainPasswordPolicyTestData(stricterLength, saved["pwdHistoryLength"], true, maxAge),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_domain_password_policy.test_acc_policy", "min_password_length", strconv.Itoa(stricterLength)),
+					resource.TestCheckResourceAttr("activedirectory_domain_password_policy.test_acc_policy", "complexity_enabled", "true"),
+					testAccCheckDomainPasswordPolicyRemote(stricterLength, true),
+				),
+			}, {
+				// back to saved values
+				Config: testAccResourceADDomainPasswordPolicyTestData(minLength, saved["pwdHistoryLength"], complexity, maxAge),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainPasswordPolicyRemote(minLength, complexity),
+				),
+			}, {
+				ResourceName: "activedirectory_domain_password_policy.test_acc_policy",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return testAccProvider.Meta().(*ADClient).config.domainDN, nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceADDomainPasswordPolicyTestData(minLength int, historyLength string, complexity bool, maxAge string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_domain_password_policy" "test_acc_policy" {
+	min_password_length     = %d
+	password_history_length = %s
+	complexity_enabled      = %t
+	max_password_age        = "%s"
+}
+`, minLength, historyLength, complexity, maxAge)
+}
+
+// testAccSaveDomainPasswordPolicy returns raw values of policy attributes of the domain object
+func testAccSaveDomainPasswordPolicy(t *testing.T) map[string]string {
+	c := testAccProvider.Meta().(*ADClient)
+	if err := c.initialiseConn(); err != nil {
+		t.Fatalf("unable to connect to LDAP server err:%v", err)
+	}
+	defer c.done()
+
+	e, err := getObjectAttributes(c.conn, c.config.domainDN, testAccDomainPasswordPolicyAttributes)
+	if err != nil {
+		t.Fatalf("unable to read domain object err:%v", err)
+	}
+	saved := map[string]string{}
+	for _, attr := range testAccDomainPasswordPolicyAttributes {
+		saved[attr] = e.GetAttributeValue(attr)
+	}
+	return saved
+}
+
+// testAccSavedComplexity returns complexity flag of saved pwdProperties value
+func testAccSavedComplexity(saved map[string]string) (bool, error) {
+	properties, err := strconv.ParseUint(saved["pwdProperties"], 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse pwdProperties value:%s err:%w", saved["pwdProperties"], err)
+	}
+	return properties&domainPasswordComplex != 0, nil
+}
+
+// testAccRestoreDomainPasswordPolicy writes saved values of policy attributes back to the domain object
+func testAccRestoreDomainPasswordPolicy(t *testing.T, saved map[string]string) {
+	c := testAccProvider.Meta().(*ADClient)
+	if err := c.initialiseConn(); err != nil {
+		t.Errorf("unable to connect to LDAP server to restore domain password policy err:%v", err)
+		return
+	}
+	defer c.done()
+
+	modReq := ldap.NewModifyRequest(c.config.domainDN, []ldap.Control{})
+	for _, attr := range testAccDomainPasswordPolicyAttributes {
+		modReq.Replace(attr, []string{saved[attr]})
+	}
+	if err := c.conn.Modify(modReq); err != nil {
+		t.Errorf("unable to restore domain password policy %v err:%v", saved, err)
+	}
+}
+
+// testAccCheckDomainPasswordPolicyRemote checks minimum length and complexity of remote domain password policy
+func testAccCheckDomainPasswordPolicyRemote(minLength int, complexity bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testAccProvider.Meta().(*ADClient)
+		if err := c.initialiseConn(); err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		policy, err := getDomainPasswordPolicy(c)
+		if err != nil {
+			return err
+		}
+		if policy.minLength != minLength || policy.complexity != complexity {
+			return fmt.Errorf("domain password policy minPwdLength:%d complexity:%v, want minPwdLength:%d complexity:%v", policy.minLength, policy.complexity, minLength, complexity)
+		}
+		return nil
+	}
+}
+
+// testAccCheckDomainPasswordPolicyDestroy makes sure destroy doesn't reset password policy of the domain.
+func testAccCheckDomainPasswordPolicyDestroy(saved map[string]string) error {
+	minLength, err := strconv.Atoi(saved["minPwdLength"])
+	if err != nil {
+		return err
+	}
+	complexity, err := testAccSavedComplexity(saved)
+	if err != nil {
+		return err
+	}
+	if err := testAccCheckDomainPasswordPolicyRemote(minLength, complexity)(nil); err != nil {
+		return fmt.Errorf("domain password policy changed on destroy: %w", err)
+	}
+	return nil
+}
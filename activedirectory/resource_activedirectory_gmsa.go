@@ -0,0 +1,351 @@
+package activedirectory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// defaultMSAContainer is RDN of the default container of managed service accounts in the domain
+const defaultMSAContainer = "cn=managed service accounts"
+
+// validateMSAContainer validates base_ou_dn of managed service account, the default container of the domain
+// is accepted even if it's outside of top dn since it's used when base_ou_dn is not set.
+func validateMSAContainer(c *ADClient, ou string) error {
+	pdn, err := ldap.ParseDN(strings.ToLower(ou))
+	if err == nil {
+		if defaultDN, err := ldap.ParseDN(defaultMSAContainer + "," + strings.ToLower(c.config.domainDN)); err == nil && defaultDN.Equal(pdn) {
+			return nil
+		}
+	}
+	return validateDNString(c, ou)
+}
+
+func resourceActivedirectoryGMSA() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the Object",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"base_ou_dn": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The full path of the Organizational Unit (OU) or container where the object is created, default is 'CN=Managed Service Accounts' container of the domain",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"sam_account_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The sAMAccountName attribute is a logon name of the account, it must end with '$' and it's limited to 15 characters without '$'.",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateMSASAMAccountName,
+			},
+			"dns_host_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The DNS host name of the account (dNSHostName)",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"service_principal_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The service principal names of the account (servicePrincipalName)",
+				Set:         lowercaseHashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"managed_password_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ForceNew:     true,
+				Description:  "The number of days after which managed password is changed (msDS-ManagedPasswordInterval), it can only be set when account is created",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"principals_allowed_to_retrieve_password": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The DNs or SIDs of groups and computers which are allowed to retrieve managed password (msDS-GroupMSAMembership)",
+				Set:         lowercaseHashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateDNOrSID,
+				},
+			},
+			"supported_encryption_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "The Kerberos encryption types supported by the account (msDS-SupportedEncryptionTypes)",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(encryptionTypeNames(), false),
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the AD object",
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the object",
+			},
+			"attributes": {
+				Type:         schema.TypeString,
+				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
+				Optional:     true,
+				ValidateFunc: validateAttributesJSON,
+				StateFunc:    normalizeAttributesJSON,
+				Default:      "{}",
+			},
+		},
+		Create: resourceCreateGMSA,
+		Read:   resourceReadGMSA,
+		Update: resourceUpdateGMSA,
+		Delete: resourceDeleteObject,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCreateGMSA(d *schema.ResourceData, meta interface{}) error {
+	var err error
+	c := meta.(*ADClient)
+	err = c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateGMSA: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	ou := d.Get("base_ou_dn").(string)
+	if ou == "" {
+		ou = defaultMSAContainer + "," + c.config.domainDN
+		if err := d.Set("base_ou_dn", ou); err != nil {
+			return fmt.Errorf("resourceCreateGMSA: unable to update 'base_ou_dn' err:%w", err)
+		}
+	} else if err := validateMSAContainer(c, ou); err != nil {
+		return fmt.Errorf("resourceCreateGMSA: base_ou_dn is not valid err: %w", err)
+	}
+
+	sids, err := getObjectSIDs(c, setToStringList(d.Get("principals_allowed_to_retrieve_password").(*schema.Set)))
+	if err != nil {
+		return fmt.Errorf("resourceCreateGMSA: unable to resolve 'principals_allowed_to_retrieve_password' err:%w", err)
+	}
+
+	addReq, err := gmsaSchemaToAddRequest(d, sids)
+	if err != nil {
+		return fmt.Errorf("resourceCreateGMSA: unable to convert schema to addrequest err:%w", err)
+	}
+	c.logger.Debug("resourceCreateGMSA: ldap add request", "addReq", addReq)
+
+	guid, err := addObject(c.conn, addReq)
+	if err != nil {
+		return fmt.Errorf("resourceCreateGMSA: unable to create group managed service account err: %w", err)
+	}
+	c.logger.Info("resourceCreateGMSA: group managed service account added to active directory", "guid", guid)
+	d.SetId(guid)
+	return resourceReadGMSA(d, meta)
+}
+
+func resourceReadGMSA(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadGMSA: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadGMSA: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadGMSA: object not found", "GUID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadGMSA: unable to search group managed service account with ID  GUID:%v err:%w", d.Id(), err)
+	}
+	c.logger.Info("resourceReadGMSA: group managed service account object found", "dn", e.DN)
+
+	if err := updateObjectSchema(resourceActivedirectoryGMSA().Schema, e, d); err != nil {
+		return err
+	}
+
+	sids, err := getGroupMSAMembership(c.conn, e.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadGMSA: unable to read 'msDS-GroupMSAMembership' err:%w", err)
+	}
+	// configured principals which no longer exist are removed from state
+	configured := map[string]string{}
+	for _, p := range setToStringList(d.Get("principals_allowed_to_retrieve_password").(*schema.Set)) {
+		resolved, err := getObjectSIDs(c, []string{p})
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				continue
+			}
+			return fmt.Errorf("resourceReadGMSA: unable to resolve principal:%s err:%w", p, err)
+		}
+		configured[p] = resolved[p]
+	}
+	principals := flattenPrincipals(sids, configured)
+	if err := d.Set("principals_allowed_to_retrieve_password", schema.NewSet(lowercaseHashString, stringListToInterfaces(principals))); err != nil {
+		return fmt.Errorf("resourceReadGMSA: unable to update 'principals_allowed_to_retrieve_password' argument value:%v err:%w", principals, err)
+	}
+	return nil
+}
+
+func resourceUpdateGMSA(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdateGMSA: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	// check if DN is changed
+	if d.HasChanges("name", "base_ou_dn") {
+		oldName, newName := d.GetChange("name")
+		oldOU, newOU := d.GetChange("base_ou_dn")
+		if err := validateMSAContainer(c, newOU.(string)); err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: new base_ou_dn is not valid err: %w", err)
+		}
+		if err := renameObject(c, "cn", oldName.(string), oldOU.(string), newName.(string), newOU.(string)); err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: %w", err)
+		}
+	}
+
+	modReq := &ldap.ModifyRequest{DN: "cn=" + d.Get("name").(string) + "," + d.Get("base_ou_dn").(string)}
+
+	if d.HasChange("sam_account_name") {
+		modReq.Replace("sAMAccountName", []string{d.Get("sam_account_name").(string)})
+		c.logger.Debug("resourceUpdateGMSA: updating 'sam_account_name'", "new", d.Get("sam_account_name").(string))
+	}
+
+	if d.HasChange("dns_host_name") {
+		modReq.Replace("dNSHostName", []string{d.Get("dns_host_name").(string)})
+		c.logger.Debug("resourceUpdateGMSA: updating 'dns_host_name'", "new", d.Get("dns_host_name").(string))
+	}
+
+	if d.HasChange("service_principal_names") {
+		spns := setToStringList(d.Get("service_principal_names").(*schema.Set))
+		modReq.Replace("servicePrincipalName", spns)
+		c.logger.Debug("resourceUpdateGMSA: updating 'service_principal_names'", "new", spns)
+	}
+
+	if d.HasChange("supported_encryption_types") {
+		types := setToStringList(d.Get("supported_encryption_types").(*schema.Set))
+		e, err := getObjectAttributes(c.conn, modReq.DN, []string{"msDS-SupportedEncryptionTypes"})
+		if err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: unable to read 'msDS-SupportedEncryptionTypes' err:%w", err)
+		}
+		v, err := encodeEncryptionTypes(types, e.GetAttributeValue("msDS-SupportedEncryptionTypes"))
+		if err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: unable to convert 'supported_encryption_types' err:%w", err)
+		}
+		modReq.Replace("msDS-SupportedEncryptionTypes", []string{v})
+		c.logger.Debug("resourceUpdateGMSA: updating 'supported_encryption_types'", "new", types)
+	}
+
+	if d.HasChange("principals_allowed_to_retrieve_password") {
+		sids, err := getObjectSIDs(c, setToStringList(d.Get("principals_allowed_to_retrieve_password").(*schema.Set)))
+		if err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: unable to resolve 'principals_allowed_to_retrieve_password' err:%w", err)
+		}
+		v, err := encodeGroupMSAMembership(sids)
+		if err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: unable to convert 'principals_allowed_to_retrieve_password' err:%w", err)
+		}
+		modReq.Replace("msDS-GroupMSAMembership", []string{v})
+		c.logger.Debug("resourceUpdateGMSA: updating 'principals_allowed_to_retrieve_password'", "new", sids)
+	}
+
+	if d.HasChange("description") {
+		if d.Get("description").(string) == "" {
+			modReq.Replace("description", []string{})
+		} else {
+			modReq.Replace("description", []string{d.Get("description").(string)})
+		}
+		c.logger.Debug("resourceUpdateGMSA: updating 'description'", "new", d.Get("description").(string))
+	}
+
+	addAttributesToModifyRequest(c, d, modReq)
+
+	if len(modReq.Changes) > 0 {
+		if err = c.conn.Modify(modReq); err != nil {
+			return fmt.Errorf("resourceUpdateGMSA: unable to update some attributes of LDAP object: ModifyRequest:%#v err:%w", modReq, err)
+		}
+		c.logger.Info("resourceUpdateGMSA: modified", "dn", modReq.DN)
+	}
+	return resourceReadGMSA(d, meta)
+}
+
+func gmsaSchemaToAddRequest(d *schema.ResourceData, sids map[string]string) (*ldap.AddRequest, error) {
+	var addReq ldap.AddRequest
+
+	name := d.Get("name").(string)
+	attributes := d.Get("attributes").(string)
+
+	addReq.DN = "cn=" + name + "," + d.Get("base_ou_dn").(string)
+
+	// add attributes
+	attrMap := map[string][]string{}
+	_ = json.Unmarshal([]byte(attributes), &attrMap)
+	for name, values := range attrMap {
+		addReq.Attribute(name, values)
+	}
+
+	membership, err := encodeGroupMSAMembership(sids)
+	if err != nil {
+		return nil, err
+	}
+
+	addReq.Attribute("sAMAccountName", []string{d.Get("sam_account_name").(string)})
+	// gMSA is workstation trust account
+	addReq.Attribute("userAccountControl", []string{"4096"})
+	addReq.Attribute("objectClass", []string{"msDS-GroupManagedServiceAccount"})
+	addReq.Attribute("name", []string{name})
+	addReq.Attribute("cn", []string{name})
+	addReq.Attribute("dNSHostName", []string{d.Get("dns_host_name").(string)})
+	addReq.Attribute("msDS-ManagedPasswordInterval", []string{strconv.Itoa(d.Get("managed_password_interval").(int))})
+	addReq.Attribute("msDS-GroupMSAMembership", []string{membership})
+	if spns := setToStringList(d.Get("service_principal_names").(*schema.Set)); len(spns) > 0 {
+		addReq.Attribute("servicePrincipalName", spns)
+	}
+	if types := setToStringList(d.Get("supported_encryption_types").(*schema.Set)); len(types) > 0 {
+		v, err := encodeEncryptionTypes(types, "")
+		if err != nil {
+			return nil, err
+		}
+		addReq.Attribute("msDS-SupportedEncryptionTypes", []string{v})
+	}
+	if d.Get("description").(string) != "" {
+		addReq.Attribute("description", []string{d.Get("description").(string)})
+	}
+
+	return &addReq, nil
+}
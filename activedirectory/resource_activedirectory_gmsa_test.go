@@ -0,0 +1,159 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("activedirectory_gmsa", &resource.Sweeper{
+		Name: "activedirectory_gmsa",
+		F: func(r string) error {
+			client, err := sharedClient()
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			c := client.(*ADClient)
+
+			err = c.initialiseConn()
+			if err != nil {
+				return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+			}
+			defer c.done()
+			entries, err := getObjectsBySAM(c, "test_acc_gmsa*")
+			if err != nil {
+				return err
+			}
+			var unDeleted []string
+			for _, e := range entries {
+				c.logger.Info("Sweep test deleting object...", "DN", e.DN)
+				request := ldap.DelRequest{DN: e.DN}
+				err = c.conn.Del(&request)
+				if err != nil {
+					c.logger.Error("unable to delete object", "DN", e.DN, "err", err)
+					unDeleted = append(unDeleted, e.DN)
+				}
+			}
+			if len(unDeleted) != 0 {
+				return fmt.Errorf("unable to delete object, DNs: %s", unDeleted)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccGMSA_Basic(t *testing.T) {
+	domain := os.Getenv("AD_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGMSADestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with only required argument defined in default container
+				Config: fmt.Sprintf(`resource "activedirectory_gmsa" "test_acc_gmsa1" {
+					name             = "test_acc_gmsa1"
+					sam_account_name = "test_acc_gmsa1$"
+					dns_host_name    = "test_acc_gmsa1.%s"
+				}`, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_gmsa.test_acc_gmsa1"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "name", "test_acc_gmsa1"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "sam_account_name", "test_acc_gmsa1$"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "managed_password_interval", "30"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "principals_allowed_to_retrieve_password.#", "0"),
+					resource.TestMatchResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "dn", regexp.MustCompile("(?i)^CN=test_acc_gmsa1,CN=Managed Service Accounts,")),
+				),
+			}, {
+				// rename object in default container
+				Config: fmt.Sprintf(`resource "activedirectory_gmsa" "test_acc_gmsa1" {
+					name             = "test_acc_gmsa1_renamed"
+					sam_account_name = "test_acc_gmsa1$"
+					dns_host_name    = "test_acc_gmsa1.%s"
+				}`, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_gmsa.test_acc_gmsa1"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "name", "test_acc_gmsa1_renamed"),
+					resource.TestMatchResourceAttr("activedirectory_gmsa.test_acc_gmsa1", "dn", regexp.MustCompile("(?i)^CN=test_acc_gmsa1_renamed,CN=Managed Service Accounts,")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGMSA_Advanced(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGMSADestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with principal set by dn
+				Config: testAccResourceADGMSATestData("activedirectory_group.test_acc_group_gmsa1.dn", `"RC4", "AES128", "AES256"`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_gmsa.test_acc_gmsa2"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa2", "base_ou_dn", baseOU),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa2", "service_principal_names.#", "2"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa2", "supported_encryption_types.#", "3"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa2", "principals_allowed_to_retrieve_password.#", "1"),
+				),
+			}, {
+				// change principal using SID and encryption types
+				Config: testAccResourceADGMSATestData("activedirectory_group.test_acc_group_gmsa2.sid", `"AES256"`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_gmsa.test_acc_gmsa2"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa2", "supported_encryption_types.#", "1"),
+					resource.TestCheckResourceAttr("activedirectory_gmsa.test_acc_gmsa2", "principals_allowed_to_retrieve_password.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADGMSATestData(principal, encryptionTypes, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_group" "test_acc_group_gmsa1" {
+	name             = "test_acc_group_gmsa1"
+	sam_account_name = "test_acc_group_gmsa1"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_gmsa2" {
+	name             = "test_acc_group_gmsa2"
+	sam_account_name = "test_acc_group_gmsa2"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_gmsa" "test_acc_gmsa2" {
+	name                                    = "test_acc_gmsa2"
+	sam_account_name                        = "test_acc_gmsa2$"
+	base_ou_dn                              = "%s"
+	dns_host_name                           = "test_acc_gmsa2.%s"
+	service_principal_names                 = ["HTTP/test_acc_gmsa2.%s", "HTTP/test_acc_gmsa2"]
+	principals_allowed_to_retrieve_password = [%s]
+	supported_encryption_types              = [%s]
+	description                             = "testing description"
+}
+`, baseOU, baseOU, baseOU, domain, domain, principal, encryptionTypes)
+}
+
+func testAccCheckGMSADestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "activedirectory_gmsa" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
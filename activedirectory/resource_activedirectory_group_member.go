@@ -0,0 +1,213 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceActivedirectoryGroupMember() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"group_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The group's dn, to add AD object from member_dn argument.",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if _, err := ldap.ParseDN(v); err != nil {
+						errs = append(errs, fmt.Errorf("group_dn should be valid DN, got value:%s err:%v", v, err))
+					}
+					return
+				},
+			},
+			"member_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The dn of the object to add to group.",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if _, err := ldap.ParseDN(v); err != nil {
+						errs = append(errs, fmt.Errorf("member_dn should be valid DN, got value:%s err:%v", v, err))
+					}
+					return
+				},
+			},
+		},
+		Create: resourceCreateGroupMember,
+		Read:   resourceReadGroupMember,
+		Delete: resourceDeleteGroupMember,
+		Importer: &schema.ResourceImporter{
+			State: resourceImportGroupMember,
+		},
+	}
+}
+
+func resourceCreateGroupMember(d *schema.ResourceData, meta interface{}) error {
+	var err error
+	c := meta.(*ADClient)
+	err = c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	groupDN := d.Get("group_dn").(string)
+	memberDN := d.Get("member_dn").(string)
+	if err := validateDNString(c, groupDN); err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: group_dn is not valid err: %w", err)
+	}
+
+	// make sure both group and member exists
+	group, err := getObjectByDN(c.conn, groupDN)
+	if err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: unable to search group with dn:%v err:%w", groupDN, err)
+	}
+	groupGUID, err := decodeGUID(group.GetRawAttributeValue("objectGUID"))
+	if err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: unable to convert raw GUID of group to string err:%w", err)
+	}
+	member, err := getObjectByDN(c.conn, memberDN)
+	if err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: unable to search member with dn:%v err:%w", memberDN, err)
+	}
+	memberGUID, err := decodeGUID(member.GetRawAttributeValue("objectGUID"))
+	if err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: unable to convert raw GUID of member to string err:%w", err)
+	}
+
+	if err := addObjectToGroup(c.conn, group.DN, member.DN); err != nil {
+		return fmt.Errorf("resourceCreateGroupMember: unable to add object:%s to group:%s err:%w", member.DN, group.DN, err)
+	}
+	c.logger.Info("resourceCreateGroupMember: AD object added to group", "group", group.DN, "member", member.DN)
+
+	d.SetId(groupGUID + "/" + memberGUID)
+	return resourceReadGroupMember(d, meta)
+}
+
+func resourceReadGroupMember(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadGroupMember: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	group, member, err := getGroupMemberByID(c, d.Id())
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadGroupMember: group or member not found", "ID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadGroupMember: %w", err)
+	}
+
+	isMember, err := isObjectMemberOfGroup(c.conn, group.DN, member.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadGroupMember: unable to check membership of object:%s group:%s err:%w", member.DN, group.DN, err)
+	}
+	if !isMember {
+		c.logger.Error("resourceReadGroupMember: object is not a member of group", "group", group.DN, "member", member.DN)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("group_dn", group.DN); err != nil {
+		return fmt.Errorf("resourceReadGroupMember: unable to update 'group_dn' argument value:%v err:%w", group.DN, err)
+	}
+	if err := d.Set("member_dn", member.DN); err != nil {
+		return fmt.Errorf("resourceReadGroupMember: unable to update 'member_dn' argument value:%v err:%w", member.DN, err)
+	}
+	return nil
+}
+
+func resourceDeleteGroupMember(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceDeleteGroupMember: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	group, member, err := getGroupMemberByID(c, d.Id())
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return fmt.Errorf("resourceDeleteGroupMember: %w", err)
+	}
+
+	if err := removeObjectFromGroup(c.conn, group.DN, member.DN); err != nil {
+		return fmt.Errorf("resourceDeleteGroupMember: unable to remove object:%s from group:%s err:%w", member.DN, group.DN, err)
+	}
+	c.logger.Info("resourceDeleteGroupMember: AD object removed from group", "group", group.DN, "member", member.DN)
+	return nil
+}
+
+func resourceImportGroupMember(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseGroupMemberID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseGroupMemberID splits resource ID in format 'groupGUID/memberGUID' and returns encoded GUIDs.
+func parseGroupMemberID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("ID should be in format 'groupGUID/memberGUID', got: %s", id)
+	}
+	groupID, err := encodeGUID(parts[0])
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encode group GUID:%v err:%w", parts[0], err)
+	}
+	memberID, err := encodeGUID(parts[1])
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encode member GUID:%v err:%w", parts[1], err)
+	}
+	return groupID, memberID, nil
+}
+
+func getGroupMemberByID(c *ADClient, id string) (*ldap.Entry, *ldap.Entry, error) {
+	groupID, memberID, err := parseGroupMemberID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	group, err := getObjectByID(c, groupID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to search group with ID:%v err:%w", id, err)
+	}
+	member, err := getObjectByID(c, memberID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to search member with ID:%v err:%w", id, err)
+	}
+	return group, member, nil
+}
+
+// isObjectMemberOfGroup checks group's member attribute for given object dn without reading all members of the group.
+func isObjectMemberOfGroup(conn *ldap.Conn, groupDN, objectDN string) (bool, error) {
+	sReq := &ldap.SearchRequest{
+		BaseDN:       groupDN,
+		Scope:        ldap.ScopeBaseObject,
+		DerefAliases: ldap.NeverDerefAliases,
+		Filter:       "(member=" + ldap.EscapeFilter(objectDN) + ")",
+		Attributes:   []string{"distinguishedName"},
+	}
+
+	sr, err := conn.Search(sReq)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, 32) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(sr.Entries) > 0, nil
+}
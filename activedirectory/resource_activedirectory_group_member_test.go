@@ -0,0 +1,119 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccGroupMember_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGroupMemberPairDestroy,
+		Steps: []resource.TestStep{
+			{
+				// add computer to a group
+				Config: testAccResourceADGroupMemberTestData(baseOU, "activedirectory_computer.test_acc_comp1.dn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckGroupMemberPairRemote("activedirectory_group_member.test_acc_group_member"),
+					resource.TestCheckResourceAttr("activedirectory_group_member.test_acc_group_member", "group_dn", "CN=test_acc_group1,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_group_member.test_acc_group_member", "member_dn", "CN=test_acc_comp1,"+baseOU),
+				),
+			}, {
+				// replace member of a group
+				Config: testAccResourceADGroupMemberTestData(baseOU, "activedirectory_computer.test_acc_comp2.dn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckGroupMemberPairRemote("activedirectory_group_member.test_acc_group_member"),
+					resource.TestCheckResourceAttr("activedirectory_group_member.test_acc_group_member", "group_dn", "CN=test_acc_group1,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_group_member.test_acc_group_member", "member_dn", "CN=test_acc_comp2,"+baseOU),
+				),
+			}, {
+				ResourceName:      "activedirectory_group_member.test_acc_group_member",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// also create 2 computer and 1 group resource to test membership
+func testAccResourceADGroupMemberTestData(baseOU, member string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_computer" "test_acc_comp1" {
+	name             = "test_acc_comp1"
+	sam_account_name = "test_acc_comp1$"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_computer" "test_acc_comp2" {
+	name             = "test_acc_comp2"
+	sam_account_name = "test_acc_comp2$"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group1" {
+	name             = "test_acc_group1"
+	sam_account_name = "test_acc_group1"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group_member" "test_acc_group_member" {
+	group_dn  = activedirectory_group.test_acc_group1.dn
+	member_dn = %s
+}
+`, baseOU, baseOU, baseOU, member)
+}
+
+func testAccCheckGroupMemberPairDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "activedirectory_group_member" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testAccCheckGroupMemberPairRemote checks membership of object on remote group
+func testAccCheckGroupMemberPairRemote(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		c := testAccProvider.Meta().(*ADClient)
+		err := c.initialiseConn()
+		if err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		group, member, err := getGroupMemberByID(c, rs.Primary.ID)
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				return fmt.Errorf("group or member of resource %s not found", resource)
+			}
+			return err
+		}
+		isMember, err := isObjectMemberOfGroup(c.conn, group.DN, member.DN)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return fmt.Errorf("object %s is not a member of group %s", member.DN, group.DN)
+		}
+		return nil
+	}
+}
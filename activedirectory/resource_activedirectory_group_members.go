@@ -20,17 +20,11 @@ func resourceActivedirectoryGroupMembers() *schema.Resource {
 			"members": {
 				Type:        schema.TypeSet,
 				Required:    true,
-				Description: "List of object's dn to add to group.",
+				Description: "List of object's dn, GUID, SID, userPrincipalName or sAMAccountName to add to group.",
 				Set:         lowercaseHashString,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-						v := val.(string)
-						if _, err := ldap.ParseDN(v); err != nil {
-							errs = append(errs, fmt.Errorf("member entry should be valid DN, got value:%s err:%v", v, err))
-						}
-						return
-					},
+					Type:         schema.TypeString,
+					ValidateFunc: validateObjectIdentity,
 				},
 			},
 		},
@@ -69,24 +63,16 @@ func resourceCreateGroupMembers(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("resourceCreateGroupMembers: unable to convert raw GUID to string rawGUID:%x err:%w", rawGUID, err)
 	}
 
-	var objectDN []string
-	if member.Len() > 0 {
-		for _, m := range member.List() {
-			objectDN = append(objectDN, m.(string))
-		}
+	objectDN, notFound, err := getObjectDNs(c, setToStringList(member))
+	if err != nil {
+		return fmt.Errorf("resourceCreateGroupMembers: %w", err)
+	}
+	if len(notFound) > 0 {
+		return fmt.Errorf("resourceCreateGroupMembers: unable to find members: %s", notFound)
 	}
 
-	// add each object just in case its already a member
-	for _, o := range objectDN {
-		modReq := &ldap.ModifyRequest{DN: groupDN}
-		modReq.Add("member", []string{o})
-		if err := c.conn.Modify(modReq); err != nil {
-			// if we get result Code 68 "Entry Already Exists" we continue
-			if ldap.IsErrorWithCode(err, 68) {
-				continue
-			}
-			return fmt.Errorf("unable to add objects to group: %s, err:%w", groupDN, err)
-		}
+	if err := addMembersToGroup(c.conn, groupDN, objectDN); err != nil {
+		return fmt.Errorf("resourceCreateGroupMembers: unable to add objects to group: %s, err:%w", groupDN, err)
 	}
 
 	// set GUID of group as resource ID
@@ -116,9 +102,24 @@ func resourceReadGroupMembers(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("resourceReadGroupMembers: unable to search group with ID:%v err:%w", d.Id(), err)
 	}
 
+	identities := setToStringList(d.Get("members").(*schema.Set))
 	if err := updateObjectSchema(resourceActivedirectoryGroupMembers().Schema, entry, d); err != nil {
 		return err
 	}
+
+	// store members as dn, GUID, SID or name same as configured so renamed or moved objects doesn't show any changes
+	resolved, err := getNameIdentityDNs(c, identities)
+	if err != nil {
+		return fmt.Errorf("resourceReadGroupMembers: %w", err)
+	}
+	memValues, err := getExtendedDNAttributeValues(c.conn, entry.DN, "member")
+	if err != nil {
+		return fmt.Errorf("resourceReadGroupMembers: unable to get members of group:%s err:%w", entry.DN, err)
+	}
+	members := matchObjectIdentities(identities, resolved, memValues)
+	if err := d.Set("members", schema.NewSet(lowercaseHashString, stringListToInterfaces(members))); err != nil {
+		return fmt.Errorf("resourceReadGroupMembers: unable to update 'members' argument value:%v err:%w", members, err)
+	}
 	return nil
 }
 
@@ -134,25 +135,33 @@ func resourceUpdateGroupMembers(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("'activedirectory_group_members' will not make any changes to group DN. group_dn is only used to as reference")
 	}
 
-	modReq := &ldap.ModifyRequest{DN: d.Get("group_dn").(string)}
+	groupDN := d.Get("group_dn").(string)
 
-	var replaceObj []string
-	member := d.Get("members").(*schema.Set)
-	for _, v := range member.List() {
-		replaceObj = append(replaceObj, v.(string))
+	old, new := d.GetChange("members")
+
+	// members can be referenced by dn, GUID or SID so compare resolved dns.
+	// objects which are already deleted are also removed from the group by AD.
+	oldDNs, _, err := getObjectDNs(c, setToStringList(old.(*schema.Set)))
+	if err != nil {
+		return fmt.Errorf("resourceUpdateGroupMembers: %w", err)
 	}
-	if len(replaceObj) > 0 {
-		modReq.Replace("member", replaceObj)
-	} else {
-		modReq.Replace("member", []string{})
+	newDNs, notFound, err := getObjectDNs(c, setToStringList(new.(*schema.Set)))
+	if err != nil {
+		return fmt.Errorf("resourceUpdateGroupMembers: %w", err)
+	}
+	if len(notFound) > 0 {
+		return fmt.Errorf("resourceUpdateGroupMembers: unable to find members: %s", notFound)
 	}
 
-	c.logger.Debug("resourceUpdateGroupMembers", "modify_request", modReq)
+	// only send the difference so members added by other tools are not touched
+	added, removed := diffDNs(oldDNs, newDNs)
+	c.logger.Debug("resourceUpdateGroupMembers", "group_dn", groupDN, "added", added, "removed", removed)
 
-	if len(modReq.Changes) > 0 {
-		if err = c.conn.Modify(modReq); err != nil {
-			return fmt.Errorf("resourceUpdateGroupMembers: unable to update group membership err: %w", err)
-		}
+	if err := addMembersToGroup(c.conn, groupDN, added); err != nil {
+		return fmt.Errorf("resourceUpdateGroupMembers: unable to add members to group:%s err: %w", groupDN, err)
+	}
+	if err := removeMembersFromGroup(c.conn, groupDN, removed); err != nil {
+		return fmt.Errorf("resourceUpdateGroupMembers: unable to remove members from group:%s err: %w", groupDN, err)
 	}
 	return resourceReadGroupMembers(d, meta)
 }
@@ -169,19 +178,60 @@ func resourceDeleteGroupMembers(d *schema.ResourceData, meta interface{}) error
 	groupDN := d.Get("group_dn").(string)
 	member := d.Get("members").(*schema.Set)
 
-	var objectDN []string
-	if member.Len() > 0 {
-		for _, m := range member.List() {
-			objectDN = append(objectDN, m.(string))
-		}
+	objectDN, _, err := getObjectDNs(c, setToStringList(member))
+	if err != nil {
+		return fmt.Errorf("resourceDeleteGroupMembers: %w", err)
 	}
 
-	modReq := &ldap.ModifyRequest{DN: groupDN}
-	modReq.Delete("member", objectDN)
-
-	if err := c.conn.Modify(modReq); err != nil {
+	if err := removeMembersFromGroup(c.conn, groupDN, objectDN); err != nil {
 		return fmt.Errorf("unable to delete object from group: %s, err:%w", groupDN, err)
 	}
 	c.logger.Info("resourceDeleteGroupMembers: AD object removed from group", "dn", groupDN, "members", objectDN)
 	return nil
 }
+
+// addMembersToGroup adds given objects to the group in batches of memberBatchSize values per modify request.
+// if a batch fails because one of the objects is already a member, objects of that batch are added one by one.
+func addMembersToGroup(conn *ldap.Conn, groupDN string, objectDN []string) error {
+	for _, batch := range chunkStrings(objectDN, memberBatchSize) {
+		modReq := &ldap.ModifyRequest{DN: groupDN}
+		modReq.Add("member", batch)
+		err := conn.Modify(modReq)
+		if err == nil {
+			continue
+		}
+		// if we get result Code 68 "Entry Already Exists" fallback to single adds
+		if !ldap.IsErrorWithCode(err, 68) {
+			return err
+		}
+		for _, o := range batch {
+			if err := addObjectToGroup(conn, groupDN, o); err != nil {
+				return fmt.Errorf("unable to add object:%s err:%w", o, err)
+			}
+		}
+	}
+	return nil
+}
+
+// removeMembersFromGroup removes given objects from the group in batches of memberBatchSize values per modify request.
+// if a batch fails because one of the objects is not a member, objects of that batch are removed one by one.
+func removeMembersFromGroup(conn *ldap.Conn, groupDN string, objectDN []string) error {
+	for _, batch := range chunkStrings(objectDN, memberBatchSize) {
+		modReq := &ldap.ModifyRequest{DN: groupDN}
+		modReq.Delete("member", batch)
+		err := conn.Modify(modReq)
+		if err == nil {
+			continue
+		}
+		// if we get result Code 53 "Unwilling To Perform" fallback to single deletes
+		if !ldap.IsErrorWithCode(err, 53) {
+			return err
+		}
+		for _, o := range batch {
+			if err := removeObjectFromGroup(conn, groupDN, o); err != nil {
+				return fmt.Errorf("unable to remove object:%s err:%w", o, err)
+			}
+		}
+	}
+	return nil
+}
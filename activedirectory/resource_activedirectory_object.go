@@ -0,0 +1,221 @@
+package activedirectory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceActivedirectoryObject() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object_class": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The object classes of the object, ie ['container'] or ['inetOrgPerson'], classes are set in objectClass attribute on creation",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(attrNameRegexp, "object class should be LDAP display name of the class"),
+				},
+			},
+			"rdn_attribute": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "cn",
+				Description:      "The attribute used in relative distinguished name of the object ie 'cn' or 'ou', default is 'cn'",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validation.StringMatch(attrNameRegexp, "rdn_attribute should be LDAP display name of the attribute"),
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the Object, it's the value of rdn_attribute",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"base_ou_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The full path of the Organizational Unit (OU) or container where the object is created",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the object",
+			},
+			"attributes": {
+				Type:         schema.TypeString,
+				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
+				Optional:     true,
+				ValidateFunc: validateAttributesJSON,
+				StateFunc:    normalizeAttributesJSON,
+				Default:      "{}",
+			},
+		},
+		Create:        resourceCreateObject,
+		Read:          resourceReadObject,
+		Update:        resourceUpdateObject,
+		Delete:        resourceDeleteObject,
+		CustomizeDiff: resourceCustomizeDiffObject,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCreateObject(d *schema.ResourceData, meta interface{}) error {
+	var classes []string
+	for _, v := range d.Get("object_class").([]interface{}) {
+		classes = append(classes, v.(string))
+	}
+	return createObject(d, meta, resourceActivedirectoryObject().Schema, d.Get("rdn_attribute").(string), classes)
+}
+
+func resourceReadObject(d *schema.ResourceData, meta interface{}) error {
+	return readObject(d, meta, resourceActivedirectoryObject().Schema)
+}
+
+func resourceUpdateObject(d *schema.ResourceData, meta interface{}) error {
+	return updateObject(d, meta, resourceActivedirectoryObject().Schema, d.Get("rdn_attribute").(string))
+}
+
+// createObject creates object of given classes from 'name', 'base_ou_dn', 'description' and 'attributes' arguments,
+// it's shared by resources of generic objects, resourceSchema is used to read the object back.
+func createObject(d *schema.ResourceData, meta interface{}, resourceSchema map[string]*schema.Schema, rdn string, classes []string) error {
+	var err error
+	c := meta.(*ADClient)
+	err = c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("createObject: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	if err := validateDNString(c, d.Get("base_ou_dn").(string)); err != nil {
+		return fmt.Errorf("createObject: base_ou_dn is not valid err: %w", err)
+	}
+
+	addReq := objectSchemaToAddRequest(d, rdn, classes)
+	if _, ok := resourceSchema["description"]; ok && d.Get("description").(string) != "" {
+		addReq.Attribute("description", []string{d.Get("description").(string)})
+	}
+	c.logger.Debug("createObject: ldap add request", "addReq", addReq)
+
+	guid, err := addObject(c.conn, addReq)
+	if err != nil {
+		return fmt.Errorf("createObject: unable to create object err: %w", err)
+	}
+	c.logger.Info("createObject: object added to active directory", "guid", guid)
+	d.SetId(guid)
+	return readObject(d, meta, resourceSchema)
+}
+
+func readObject(d *schema.ResourceData, meta interface{}, resourceSchema map[string]*schema.Schema) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("readObject: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("readObject: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("readObject: object not found", "GUID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("readObject: unable to search object with ID  GUID:%v err:%w", d.Id(), err)
+	}
+	c.logger.Info("readObject: object found", "dn", e.DN)
+
+	return updateObjectSchema(resourceSchema, e, d)
+}
+
+// updateObject renames or moves the object and updates its 'description' and 'attributes' arguments.
+func updateObject(d *schema.ResourceData, meta interface{}, resourceSchema map[string]*schema.Schema, rdn string) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("updateObject: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	// check if DN is changed
+	if d.HasChanges("name", "base_ou_dn") {
+		oldName, newName := d.GetChange("name")
+		oldOU, newOU := d.GetChange("base_ou_dn")
+		if err := validateDNString(c, newOU.(string)); err != nil {
+			return fmt.Errorf("updateObject: new base_ou_dn is not valid err: %w", err)
+		}
+		if err := renameObject(c, rdn, oldName.(string), oldOU.(string), newName.(string), newOU.(string)); err != nil {
+			return fmt.Errorf("updateObject: %w", err)
+		}
+	}
+
+	modReq := &ldap.ModifyRequest{DN: rdn + "=" + d.Get("name").(string) + "," + d.Get("base_ou_dn").(string)}
+
+	if _, ok := resourceSchema["description"]; ok && d.HasChange("description") {
+		if d.Get("description").(string) == "" {
+			modReq.Replace("description", []string{})
+		} else {
+			modReq.Replace("description", []string{d.Get("description").(string)})
+		}
+		c.logger.Debug("updateObject: updating 'description'", "new", d.Get("description").(string))
+	}
+	addAttributesToModifyRequest(c, d, modReq)
+
+	if len(modReq.Changes) > 0 {
+		if err = c.conn.Modify(modReq); err != nil {
+			return fmt.Errorf("updateObject: unable to update some attributes of LDAP object: ModifyRequest:%#v err:%w", modReq, err)
+		}
+		c.logger.Info("updateObject: modified", "dn", modReq.DN)
+	}
+	return readObject(d, meta, resourceSchema)
+}
+
+// resourceCustomizeDiffObject makes sure attributes managed by arguments are not also set in 'attributes'.
+func resourceCustomizeDiffObject(d *schema.ResourceDiff, meta interface{}) error {
+	attributes := d.Get("attributes").(string)
+	for _, attr := range []string{"objectClass", "name", "distinguishedName", d.Get("rdn_attribute").(string)} {
+		if isAttributeInJSON(attributes, attr) {
+			return fmt.Errorf("resourceCustomizeDiffObject: attribute '%s' is managed by resource arguments and should not be set in 'attributes'", attr)
+		}
+	}
+	return nil
+}
+
+func objectSchemaToAddRequest(d *schema.ResourceData, rdn string, classes []string) *ldap.AddRequest {
+	var addReq ldap.AddRequest
+
+	name := d.Get("name").(string)
+	attributes := d.Get("attributes").(string)
+
+	addReq.DN = rdn + "=" + name + "," + d.Get("base_ou_dn").(string)
+
+	// add attributes
+	attrMap := map[string][]string{}
+	_ = json.Unmarshal([]byte(attributes), &attrMap)
+	for name, values := range attrMap {
+		addReq.Attribute(name, values)
+	}
+
+	addReq.Attribute("objectClass", classes)
+	addReq.Attribute(rdn, []string{name})
+
+	return &addReq
+}
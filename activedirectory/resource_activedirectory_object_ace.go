@@ -0,0 +1,352 @@
+package activedirectory
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// aceSchema returns schema of ACE arguments, it's used by object ACE resource and by ace block of object ACL resource.
+func aceSchema(forceNew bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"trustee": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         forceNew,
+			Description:      "The dn or SID of the security principal the ACE applies to",
+			DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			ValidateFunc:     validateDNOrSID,
+		},
+		"access": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      "allow",
+			Description:  "The access type of the ACE, 'allow' or 'deny'",
+			ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+		},
+		"rights": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			ForceNew:    forceNew,
+			MinItems:    1,
+			Description: "The access rights of the ACE ie 'read_property', 'write_property', 'extended_right' or 'generic_all'",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateACERight,
+			},
+		},
+		"object_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         forceNew,
+			Description:      "The GUID or name of attribute, property set, validated write, extended right or child class the ACE applies to",
+			DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			ValidateFunc:     validateObjectType,
+		},
+		"inherited_object_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         forceNew,
+			Description:      "The GUID or name of the class of objects which inherit the ACE",
+			DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			ValidateFunc:     validateObjectType,
+		},
+		"inheritance": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      "none",
+			Description:  "The inheritance of the ACE, 'none', 'all', 'descendents', 'self_and_children' or 'children'",
+			ValidateFunc: validation.StringInSlice([]string{"none", "all", "descendents", "self_and_children", "children"}, false),
+		},
+	}
+}
+
+// expandACE converts ACE arguments to ace, trustee is resolved to SID using given map of trustees to SIDs.
+func expandACE(m map[string]interface{}, sids map[string]string) (ace, error) {
+	sid, ok := sids[m["trustee"].(string)]
+	if !ok {
+		return ace{}, fmt.Errorf("SID of trustee %s is not known", m["trustee"].(string))
+	}
+	mask, err := rightsToMask(setToStringList(m["rights"].(*schema.Set)))
+	if err != nil {
+		return ace{}, err
+	}
+	objectType, err := resolveObjectType(m["object_type"].(string))
+	if err != nil {
+		return ace{}, err
+	}
+	inheritedObjectType, err := resolveObjectType(m["inherited_object_type"].(string))
+	if err != nil {
+		return ace{}, err
+	}
+	return newACE(m["access"].(string) == "deny", mask, objectType, inheritedObjectType, sid, m["inheritance"].(string)), nil
+}
+
+// flattenACE converts ace to ACE arguments, configured values are kept if they represent the same ACE values.
+func flattenACE(a ace, configured map[string]interface{}, sids map[string]string) map[string]interface{} {
+	m := map[string]interface{}{
+		"trustee":               a.sid,
+		"access":                "allow",
+		"object_type":           a.objectType,
+		"inherited_object_type": a.inheritedObjectType,
+		"inheritance":           aceInheritance(a.flags),
+	}
+	if a.isDeny() {
+		m["access"] = "deny"
+	}
+	var rights []string
+	if configured != nil {
+		if trustee, ok := configured["trustee"].(string); ok && strings.EqualFold(sids[trustee], a.sid) {
+			m["trustee"] = trustee
+		}
+		if v, ok := configured["rights"].(*schema.Set); ok {
+			rights = setToStringList(v)
+		}
+		if v, ok := configured["object_type"].(string); ok {
+			m["object_type"] = flattenObjectType(a.objectType, v)
+		}
+		if v, ok := configured["inherited_object_type"].(string); ok {
+			m["inherited_object_type"] = flattenObjectType(a.inheritedObjectType, v)
+		}
+	}
+	m["rights"] = schema.NewSet(schema.HashString, stringListToInterfaces(flattenRights(a.mask, rights)))
+	return m
+}
+
+func resourceActivedirectoryObjectACE() *schema.Resource {
+	s := aceSchema(true)
+	s["object_dn"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "The dn of the object whose DACL contains the ACE",
+		DiffSuppressFunc: ignoreCaseDiffSuppressor,
+		ValidateFunc:     validateDN,
+	}
+	return &schema.Resource{
+		Schema: s,
+		Create: resourceCreateObjectACE,
+		Read:   resourceReadObjectACE,
+		Delete: resourceDeleteObjectACE,
+		Importer: &schema.ResourceImporter{
+			State: resourceImportObjectACE,
+		},
+	}
+}
+
+// objectACEArguments returns ACE arguments of object ACE resource.
+func objectACEArguments(d *schema.ResourceData) map[string]interface{} {
+	m := map[string]interface{}{}
+	for k := range aceSchema(true) {
+		m[k] = d.Get(k)
+	}
+	return m
+}
+
+// parseObjectACEID returns object GUID and ACE of object ACE resource ID, ID is '<object GUID>/<hex encoded ACE>'.
+func parseObjectACEID(id string) (string, ace, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || !isGUIDString(parts[0]) {
+		return "", ace{}, fmt.Errorf("ID should be in format '<object GUID>/<hex encoded ACE>', got: %s", id)
+	}
+	raw, err := hex.DecodeString(parts[1])
+	if err != nil || len(raw) < 4 {
+		return "", ace{}, fmt.Errorf("unable to decode ACE of ID:%s", id)
+	}
+	a, err := decodeACE(raw)
+	if err != nil {
+		return "", ace{}, fmt.Errorf("unable to decode ACE of ID:%s err:%w", id, err)
+	}
+	if a.raw != nil {
+		return "", ace{}, fmt.Errorf("unsupported ACE type %d of ID:%s", a.aceType, id)
+	}
+	return parts[0], a, nil
+}
+
+// parseObjectACEImportID returns object GUID and ACE of object ACE import ID, ID is
+// '<object GUID>/<trustee SID>/<access>/<rights>/<object type>/<inherited object type>/<inheritance>'.
+// rights are comma separated names or hexadecimal access mask, object types can be empty.
+func parseObjectACEImportID(id string) (string, ace, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 7 {
+		return "", ace{}, fmt.Errorf("ID should be in format '<object GUID>/<trustee SID>/<access>/<rights>/<object type>/<inherited object type>/<inheritance>', got: %s", id)
+	}
+	guid, sid, access, rights, inheritance := parts[0], parts[1], parts[2], parts[3], parts[6]
+	if !isGUIDString(guid) {
+		return "", ace{}, fmt.Errorf("object GUID of ID is not valid, got: %s", guid)
+	}
+	if !isSIDString(sid) {
+		return "", ace{}, fmt.Errorf("trustee SID of ID is not valid, got: %s", sid)
+	}
+	if access != "allow" && access != "deny" {
+		return "", ace{}, fmt.Errorf("access of ID should be 'allow' or 'deny', got: %s", access)
+	}
+	mask, err := rightsToMask(strings.Split(rights, ","))
+	if err != nil {
+		return "", ace{}, fmt.Errorf("rights of ID are not valid err:%w", err)
+	}
+	objectType, err := resolveObjectType(parts[4])
+	if err != nil {
+		return "", ace{}, fmt.Errorf("object type of ID is not valid err:%w", err)
+	}
+	inheritedObjectType, err := resolveObjectType(parts[5])
+	if err != nil {
+		return "", ace{}, fmt.Errorf("inherited object type of ID is not valid err:%w", err)
+	}
+	if _, ok := aceInheritanceFlags[inheritance]; !ok {
+		return "", ace{}, fmt.Errorf("inheritance of ID is not valid, got: %s", inheritance)
+	}
+	return guid, newACE(access == "deny", mask, objectType, inheritedObjectType, sid, inheritance), nil
+}
+
+// resourceImportObjectACE converts readable import ID to resource ID which contains binary ACE.
+func resourceImportObjectACE(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	guid, a, err := parseObjectACEImportID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("resourceImportObjectACE: %w", err)
+	}
+	raw, err := a.encode()
+	if err != nil {
+		return nil, fmt.Errorf("resourceImportObjectACE: unable to encode ACE err:%w", err)
+	}
+	d.SetId(guid + "/" + hex.EncodeToString(raw))
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCreateObjectACE(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	e, err := getObjectByDN(c.conn, d.Get("object_dn").(string))
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: unable to search object with dn:%v err:%w", d.Get("object_dn").(string), err)
+	}
+	guid, err := decodeGUID(e.GetRawAttributeValue("objectGUID"))
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: unable to convert raw GUID of object to string err:%w", err)
+	}
+	sids, err := getObjectSIDs(c, []string{d.Get("trustee").(string)})
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: unable to get SID of trustee err:%w", err)
+	}
+	n, err := expandACE(objectACEArguments(d), sids)
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: invalid ACE err:%w", err)
+	}
+	raw, err := n.encode()
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: unable to encode ACE err:%w", err)
+	}
+
+	err = updateObjectSecurityDescriptor(c.conn, e.DN, func(sd *securityDescriptor) (bool, error) {
+		if sd.dacl == nil {
+			return false, fmt.Errorf("object:%s doesn't have DACL", e.DN)
+		}
+		sd.dacl.addACE(n)
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACE: %w", err)
+	}
+	c.logger.Info("resourceCreateObjectACE: ACE added to object", "dn", e.DN, "trustee", n.sid)
+
+	d.SetId(guid + "/" + hex.EncodeToString(raw))
+	return resourceReadObjectACE(d, meta)
+}
+
+func resourceReadObjectACE(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACE: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	guid, a, err := parseObjectACEID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACE: %w", err)
+	}
+	id, err := encodeGUID(guid)
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACE: unable to encode GUID:%v err:%w", guid, err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadObjectACE: object not found", "GUID", guid)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadObjectACE: unable to search object with ID GUID:%v err:%w", guid, err)
+	}
+
+	sd, err := getObjectSecurityDescriptor(c.conn, e.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACE: unable to read security descriptor of object:%s err:%w", e.DN, err)
+	}
+	if sd.dacl == nil || !sd.dacl.hasACE(func(r ace) bool { return !r.isInherited() && r.equal(a) }) {
+		c.logger.Error("resourceReadObjectACE: ACE not found in DACL of object", "dn", e.DN)
+		d.SetId("")
+		return nil
+	}
+
+	// trustee of the state is kept since ACE of the ID is created for its SID
+	configured := objectACEArguments(d)
+	sids := map[string]string{}
+	if trustee := d.Get("trustee").(string); trustee != "" {
+		sids[trustee] = a.sid
+	}
+	for k, v := range flattenACE(a, configured, sids) {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("resourceReadObjectACE: unable to update '%s' argument value:%v err:%w", k, v, err)
+		}
+	}
+	if err := d.Set("object_dn", e.DN); err != nil {
+		return fmt.Errorf("resourceReadObjectACE: unable to update 'object_dn' argument value:%v err:%w", e.DN, err)
+	}
+	return nil
+}
+
+func resourceDeleteObjectACE(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACE: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	guid, a, err := parseObjectACEID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACE: %w", err)
+	}
+	id, err := encodeGUID(guid)
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACE: unable to encode GUID:%v err:%w", guid, err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return fmt.Errorf("resourceDeleteObjectACE: unable to search object with ID GUID:%v err:%w", guid, err)
+	}
+
+	err = updateObjectSecurityDescriptor(c.conn, e.DN, func(sd *securityDescriptor) (bool, error) {
+		return sd.dacl != nil && sd.dacl.removeACEs(func(r ace) bool { return !r.isInherited() && r.equal(a) }) > 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACE: %w", err)
+	}
+	c.logger.Info("resourceDeleteObjectACE: ACE removed from object", "dn", e.DN, "trustee", a.sid)
+	return nil
+}
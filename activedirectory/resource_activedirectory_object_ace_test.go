@@ -0,0 +1,229 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccObjectACE_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObjectACEDestroy,
+		Steps: []resource.TestStep{
+			{
+				// allow group to reset password of users in the ou
+				Config: testAccResourceADObjectACETestData(baseOU, "user-force-change-password"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectACERemote("activedirectory_object_ace.test_acc_ace"),
+					resource.TestCheckResourceAttr("activedirectory_object_ace.test_acc_ace", "object_dn", "OU=test_acc_ou_ace,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_object_ace.test_acc_ace", "object_type", "user-force-change-password"),
+					resource.TestCheckResourceAttr("activedirectory_object_ace.test_acc_ace", "inheritance", "descendents"),
+				),
+			}, {
+				// replace ACE with other object type given as GUID
+				Config: testAccResourceADObjectACETestData(baseOU, extendedRightChangePassword),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectACERemote("activedirectory_object_ace.test_acc_ace"),
+					resource.TestCheckResourceAttr("activedirectory_object_ace.test_acc_ace", "object_type", extendedRightChangePassword),
+				),
+			}, {
+				ResourceName:      "activedirectory_object_ace.test_acc_ace",
+				ImportState:       true,
+				ImportStateIdFunc: testAccObjectACEImportID("activedirectory_object_ace.test_acc_ace", "activedirectory_group.test_acc_group_ace"),
+				ImportStateVerify: true,
+				// imported ACE has SID of trustee and GUIDs of object types
+				ImportStateVerifyIgnore: []string{"trustee", "inherited_object_type"},
+			},
+		},
+	})
+}
+
+// TestAccObjectACE_Multiple creates several ACEs on one object in a single apply, none of them may be lost
+func TestAccObjectACE_Multiple(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	attributes := []string{"description", "displayname", "mail", "telephonenumber"}
+	var checks []resource.TestCheckFunc
+	for _, attribute := range attributes {
+		checks = append(checks, testAccCheckObjectACERemote(fmt.Sprintf("activedirectory_object_ace.test_acc_ace_multi[%q]", attribute)))
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObjectACEDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceADObjectACEMultipleTestData(baseOU, attributes),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
+
+// also create ou and group resources to grant access on
+func testAccResourceADObjectACETestData(baseOU, objectType string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_ou" "test_acc_ou_ace" {
+	name       = "test_acc_ou_ace"
+	base_ou_dn = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_ace" {
+	name             = "test_acc_group_ace"
+	sam_account_name = "test_acc_group_ace"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_object_ace" "test_acc_ace" {
+	object_dn             = activedirectory_ou.test_acc_ou_ace.dn
+	trustee               = activedirectory_group.test_acc_group_ace.dn
+	rights                = ["extended_right"]
+	object_type           = "%s"
+	inherited_object_type = "user"
+	inheritance           = "descendents"
+}
+`, baseOU, baseOU, objectType)
+}
+
+// one ACE per attribute, all on the same ou
+func testAccResourceADObjectACEMultipleTestData(baseOU string, attributes []string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_ou" "test_acc_ou_ace_multi" {
+	name       = "test_acc_ou_ace_multi"
+	base_ou_dn = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_ace_multi" {
+	name             = "test_acc_group_ace_multi"
+	sam_account_name = "test_acc_group_ace_multi"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_object_ace" "test_acc_ace_multi" {
+	for_each              = toset(["%s"])
+	object_dn             = activedirectory_ou.test_acc_ou_ace_multi.dn
+	trustee               = activedirectory_group.test_acc_group_ace_multi.dn
+	rights                = ["read_property", "write_property"]
+	object_type           = each.value
+	inherited_object_type = "user"
+	inheritance           = "descendents"
+}
+`, baseOU, baseOU, strings.Join(attributes, `", "`))
+}
+
+// testAccObjectACEImportID returns readable import ID of the ACE resource, trustee SID is taken from the group resource
+func testAccObjectACEImportID(name, group string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", name)
+		}
+		gs, ok := s.RootModule().Resources[group]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", group)
+		}
+		guid := strings.SplitN(rs.Primary.ID, "/", 2)[0]
+		return fmt.Sprintf("%s/%s/allow/extended_right/%s/user/descendents", guid, gs.Primary.Attributes["sid"], rs.Primary.Attributes["object_type"]), nil
+	}
+}
+
+func testAccCheckObjectACEDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "activedirectory_object_ace" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testAccCheckObjectACERemote checks ACE of the resource is in DACL of remote object
+func testAccCheckObjectACERemote(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		c := testAccProvider.Meta().(*ADClient)
+		err := c.initialiseConn()
+		if err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		guid, a, err := parseObjectACEID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		id, err := encodeGUID(guid)
+		if err != nil {
+			return err
+		}
+		e, err := getObjectByID(c, id)
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				return fmt.Errorf("object of resource %s not found", resource)
+			}
+			return err
+		}
+		sd, err := getObjectSecurityDescriptor(c.conn, e.DN)
+		if err != nil {
+			return err
+		}
+		if sd.dacl == nil || !sd.dacl.hasACE(func(r ace) bool { return !r.isInherited() && r.equal(a) }) {
+			return fmt.Errorf("ACE of resource %s not found in DACL of %s", resource, e.DN)
+		}
+		return nil
+	}
+}
+
+func Test_parseObjectACEImportID(t *testing.T) {
+	guid := "0b6ee84b-5ab5-4e3a-b0ba-9e7a0e45e2e1"
+	sid := "S-1-5-21-1004336348-1177238915-682003330-1105"
+	tests := []struct {
+		name    string
+		id      string
+		want    ace
+		wantErr bool
+	}{
+		{
+			name: "1",
+			id:   guid + "/" + sid + "/allow/extended_right/user-force-change-password/user/descendents",
+			want: newACE(false, adsRightDSControlAccess, "00299570-246d-11d0-a768-00aa006e0529", "bf967aba-0de6-11d0-a285-00aa003049e2", sid, "descendents"),
+		},
+		{name: "2", id: guid + "/" + sid + "/deny/0x30///none", want: newACE(true, 0x30, "", "", sid, "none")},
+		{name: "3", id: guid + "/" + sid + "/allow/read_property,write_property/member//all", want: newACE(false, 0x30, "bf9679c0-0de6-11d0-a285-00aa003049e2", "", sid, "all")},
+		{name: "4", id: guid + "/" + sid + "/allow/0x30//", wantErr: true},
+		{name: "5", id: guid + "/CN=user1,DC=example,DC=com/allow/0x30///none", wantErr: true},
+		{name: "6", id: guid + "/" + sid + "/permit/0x30///none", wantErr: true},
+		{name: "7", id: guid + "/" + sid + "/allow/full_control///none", wantErr: true},
+		{name: "8", id: guid + "/" + sid + "/allow/0x30/unknown//none", wantErr: true},
+		{name: "9", id: guid + "/" + sid + "/allow/0x30///everything", wantErr: true},
+	}
+	for _, tt := range tests {
+		gotGUID, got, err := parseObjectACEImportID(tt.id)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseObjectACEImportID() name = %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if gotGUID != guid || !got.equal(tt.want) {
+			t.Errorf("parseObjectACEImportID() name = %s Got = %s %#v, want %s %#v", tt.name, gotGUID, got, guid, tt.want)
+		}
+	}
+}
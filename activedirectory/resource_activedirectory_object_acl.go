@@ -0,0 +1,263 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceActivedirectoryObjectACL() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"object_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The dn of the object whose explicit ACEs are managed",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateDN,
+			},
+			"ace": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The explicit ACEs of the object DACL, other explicit ACEs are removed",
+				Elem: &schema.Resource{
+					Schema: aceSchema(false),
+				},
+			},
+		},
+		Create: resourceCreateObjectACL,
+		Read:   resourceReadObjectACL,
+		Update: resourceUpdateObjectACL,
+		Delete: resourceDeleteObjectACL,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// isExplicitACE matches ACEs which are managed by object ACL resource, ACEs of unsupported types are never touched.
+func isExplicitACE(a ace) bool {
+	return !a.isInherited() && a.raw == nil
+}
+
+// aceTrustees returns unique trustees of given ACE arguments.
+func aceTrustees(aces []interface{}) []string {
+	var trustees []string
+	seen := map[string]bool{}
+	for _, v := range aces {
+		trustee := v.(map[string]interface{})["trustee"].(string)
+		if !seen[trustee] {
+			seen[trustee] = true
+			trustees = append(trustees, trustee)
+		}
+	}
+	return trustees
+}
+
+// getKnownObjectSIDs is same as getObjectSIDs but skips identities which can't be resolved ie deleted objects.
+func getKnownObjectSIDs(c *ADClient, identities []string) map[string]string {
+	sids := map[string]string{}
+	for _, identity := range identities {
+		s, err := getObjectSIDs(c, []string{identity})
+		if err != nil {
+			c.logger.Debug("getKnownObjectSIDs: unable to get SID of object", "identity", identity, "err", err)
+			continue
+		}
+		sids[identity] = s[identity]
+	}
+	return sids
+}
+
+func resourceCreateObjectACL(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACL: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	e, err := getObjectByDN(c.conn, d.Get("object_dn").(string))
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACL: unable to search object with dn:%v err:%w", d.Get("object_dn").(string), err)
+	}
+	guid, err := decodeGUID(e.GetRawAttributeValue("objectGUID"))
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectACL: unable to convert raw GUID of object to string err:%w", err)
+	}
+	if err := setObjectExplicitACEs(c, e.DN, d.Get("ace").(*schema.Set).List()); err != nil {
+		return fmt.Errorf("resourceCreateObjectACL: %w", err)
+	}
+
+	d.SetId(guid)
+	return resourceReadObjectACL(d, meta)
+}
+
+func resourceReadObjectACL(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACL: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACL: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadObjectACL: object not found", "GUID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadObjectACL: unable to search object with ID GUID:%v err:%w", d.Id(), err)
+	}
+
+	sd, err := getObjectSecurityDescriptor(c.conn, e.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectACL: unable to read security descriptor of object:%s err:%w", e.DN, err)
+	}
+
+	// keep configured trustees, rights and object types of ACEs which are not changed,
+	// ACEs which don't match configuration are stored with SID of trustee.
+	configured := d.Get("ace").(*schema.Set).List()
+	sids := getKnownObjectSIDs(c, aceTrustees(configured))
+	expanded := make([]*ace, len(configured))
+	for i, v := range configured {
+		if a, err := expandACE(v.(map[string]interface{}), sids); err == nil {
+			expanded[i] = &a
+		}
+	}
+	var aces []interface{}
+	if sd.dacl != nil {
+		for _, a := range sd.dacl.aces {
+			if !isExplicitACE(a) {
+				continue
+			}
+			var m map[string]interface{}
+			for i, n := range expanded {
+				if n != nil && n.equal(a) {
+					m = flattenACE(a, configured[i].(map[string]interface{}), sids)
+					expanded[i] = nil
+					break
+				}
+			}
+			if m == nil {
+				m = flattenACE(a, nil, nil)
+			}
+			aces = append(aces, m)
+		}
+	}
+	if err := d.Set("ace", aces); err != nil {
+		return fmt.Errorf("resourceReadObjectACL: unable to update 'ace' argument value:%v err:%w", aces, err)
+	}
+	if err := d.Set("object_dn", e.DN); err != nil {
+		return fmt.Errorf("resourceReadObjectACL: unable to update 'object_dn' argument value:%v err:%w", e.DN, err)
+	}
+	return nil
+}
+
+func resourceUpdateObjectACL(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdateObjectACL: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	if d.HasChange("ace") {
+		if err := setObjectExplicitACEs(c, d.Get("object_dn").(string), d.Get("ace").(*schema.Set).List()); err != nil {
+			return fmt.Errorf("resourceUpdateObjectACL: %w", err)
+		}
+	}
+	return resourceReadObjectACL(d, meta)
+}
+
+// resourceDeleteObjectACL removes configured ACEs from the object, other explicit ACEs are not restored.
+func resourceDeleteObjectACL(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACL: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACL: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return fmt.Errorf("resourceDeleteObjectACL: unable to search object with ID GUID:%v err:%w", d.Id(), err)
+	}
+
+	configured := d.Get("ace").(*schema.Set).List()
+	sids := getKnownObjectSIDs(c, aceTrustees(configured))
+	var aces []ace
+	for _, v := range configured {
+		if a, err := expandACE(v.(map[string]interface{}), sids); err == nil {
+			aces = append(aces, a)
+		}
+	}
+
+	removed := 0
+	err = updateObjectSecurityDescriptor(c.conn, e.DN, func(sd *securityDescriptor) (bool, error) {
+		if sd.dacl == nil {
+			return false, nil
+		}
+		removed = sd.dacl.removeACEs(func(r ace) bool {
+			for _, a := range aces {
+				if isExplicitACE(r) && r.equal(a) {
+					return true
+				}
+			}
+			return false
+		})
+		return removed > 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectACL: %w", err)
+	}
+	if removed > 0 {
+		c.logger.Info("resourceDeleteObjectACL: ACEs removed from object", "dn", e.DN, "removed", removed)
+	}
+	return nil
+}
+
+// setObjectExplicitACEs replaces explicit ACEs of the object DACL with given ACE arguments, inherited ACEs are kept.
+func setObjectExplicitACEs(c *ADClient, dn string, configured []interface{}) error {
+	sids, err := getObjectSIDs(c, aceTrustees(configured))
+	if err != nil {
+		return fmt.Errorf("unable to get SIDs of trustees err:%w", err)
+	}
+	var aces []ace
+	for _, v := range configured {
+		a, err := expandACE(v.(map[string]interface{}), sids)
+		if err != nil {
+			return fmt.Errorf("invalid ACE err:%w", err)
+		}
+		aces = append(aces, a)
+	}
+
+	err = updateObjectSecurityDescriptor(c.conn, dn, func(sd *securityDescriptor) (bool, error) {
+		if sd.dacl == nil {
+			return false, fmt.Errorf("object:%s doesn't have DACL", dn)
+		}
+		sd.dacl.removeACEs(isExplicitACE)
+		for _, a := range aces {
+			sd.dacl.addACE(a)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	c.logger.Info("setObjectExplicitACEs: explicit ACEs of object replaced", "dn", dn, "count", len(aces))
+	return nil
+}
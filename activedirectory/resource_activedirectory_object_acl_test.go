@@ -0,0 +1,114 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccObjectACL_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObjectACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				// replace explicit ACEs of the ou
+				Config: testAccResourceADObjectACLTestData(baseOU, `["read_property", "list_children"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectACLRemote("activedirectory_object_acl.test_acc_acl", 2),
+					resource.TestCheckResourceAttr("activedirectory_object_acl.test_acc_acl", "object_dn", "OU=test_acc_ou_acl,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_object_acl.test_acc_acl", "ace.#", "2"),
+				),
+			}, {
+				// update rights of group ACE
+				Config: testAccResourceADObjectACLTestData(baseOU, `["generic_read"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectACLRemote("activedirectory_object_acl.test_acc_acl", 2),
+					resource.TestCheckResourceAttr("activedirectory_object_acl.test_acc_acl", "ace.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// also create ou and group resources, builtin Administrators keep full control of the ou
+func testAccResourceADObjectACLTestData(baseOU, rights string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_ou" "test_acc_ou_acl" {
+	name       = "test_acc_ou_acl"
+	base_ou_dn = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_acl" {
+	name             = "test_acc_group_acl"
+	sam_account_name = "test_acc_group_acl"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_object_acl" "test_acc_acl" {
+	object_dn = activedirectory_ou.test_acc_ou_acl.dn
+
+	ace {
+		trustee = "S-1-5-32-544"
+		rights  = ["generic_all"]
+	}
+
+	ace {
+		trustee     = activedirectory_group.test_acc_group_acl.dn
+		rights      = %s
+		inheritance = "all"
+	}
+}
+`, baseOU, baseOU, rights)
+}
+
+func testAccCheckObjectACLDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "activedirectory_object_acl" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testAccCheckObjectACLRemote checks number of explicit ACEs in DACL of remote object
+func testAccCheckObjectACLRemote(resource string, count int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+
+		c := testAccProvider.Meta().(*ADClient)
+		err := c.initialiseConn()
+		if err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		sd, err := getObjectSecurityDescriptor(c.conn, rs.Primary.Attributes["object_dn"])
+		if err != nil {
+			return err
+		}
+		var explicit int
+		if sd.dacl != nil {
+			for _, a := range sd.dacl.aces {
+				if isExplicitACE(a) {
+					explicit++
+				}
+			}
+		}
+		if explicit != count {
+			return fmt.Errorf("object %s has %d explicit ACEs, want %d", rs.Primary.Attributes["object_dn"], explicit, count)
+		}
+		return nil
+	}
+}
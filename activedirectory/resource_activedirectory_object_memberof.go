@@ -20,17 +20,11 @@ func resourceActivedirectoryObjectMemberOf() *schema.Resource {
 			"member_of": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "List of group's dns to add AD Object.",
+				Description: "List of group's dn, GUID, SID or sAMAccountName to add AD Object.",
 				Set:         lowercaseHashString,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-						v := val.(string)
-						if _, err := ldap.ParseDN(v); err != nil {
-							errs = append(errs, fmt.Errorf("member_of entry should be valid DN, got value:%s err:%v", v, err))
-						}
-						return
-					},
+					Type:         schema.TypeString,
+					ValidateFunc: validateObjectIdentity,
 				},
 			},
 		},
@@ -67,11 +61,19 @@ func resourceCreateObjectMemberOf(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("resourceCreateObjectMemberOf: unable to convert raw GUID to string rawGUID:%x err:%w", rawGUID, err)
 	}
 
-	for _, groupDN := range groupDNS.List() {
-		if err := validateDNString(c, groupDN.(string)); err != nil {
+	groups, notFound, err := getObjectDNs(c, setToStringList(groupDNS))
+	if err != nil {
+		return fmt.Errorf("resourceCreateObjectMemberOf: %w", err)
+	}
+	if len(notFound) > 0 {
+		return fmt.Errorf("resourceCreateObjectMemberOf: unable to find groups: %s", notFound)
+	}
+
+	for _, groupDN := range groups {
+		if err := validateDNString(c, groupDN); err != nil {
 			return fmt.Errorf("resourceCreateObjectMemberOf: group dn is not valid err: %w", err)
 		}
-		if err := addObjectToGroup(c.conn, groupDN.(string), objectDN); err != nil {
+		if err := addObjectToGroup(c.conn, groupDN, objectDN); err != nil {
 			return fmt.Errorf("unable to add object to group: %s, err:%w", groupDN, err)
 		}
 	}
@@ -103,10 +105,25 @@ func resourceReadObjectMemberOf(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("resourceReadObjectMemberOf: unable to search object with ID dn:%v err:%w", d.Id(), err)
 	}
 
+	identities := setToStringList(d.Get("member_of").(*schema.Set))
 	if err := updateObjectSchema(resourceActivedirectoryObjectMemberOf().Schema, entry, d); err != nil {
 		return err
 	}
 
+	// store groups as dn, GUID, SID or name same as configured so renamed or moved groups doesn't show any changes
+	resolved, err := getNameIdentityDNs(c, identities)
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectMemberOf: %w", err)
+	}
+	memOfValues, err := getExtendedDNAttributeValues(c.conn, entry.DN, "memberOf")
+	if err != nil {
+		return fmt.Errorf("resourceReadObjectMemberOf: unable to get groups of object:%s err:%w", entry.DN, err)
+	}
+	memberOf := matchObjectIdentities(identities, resolved, memOfValues)
+	if err := d.Set("member_of", schema.NewSet(lowercaseHashString, stringListToInterfaces(memberOf))); err != nil {
+		return fmt.Errorf("resourceReadObjectMemberOf: unable to update 'member_of' argument value:%v err:%w", memberOf, err)
+	}
+
 	return nil
 }
 
@@ -124,20 +141,30 @@ func resourceUpdateObjectMemberOf(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	old, new := d.GetChange("member_of")
-	oldGroups := old.(*schema.Set)
-	newGroups := new.(*schema.Set)
-
-	// get unique value from set
-	uniqueNew := newGroups.Difference(oldGroups)
-	uniqueOld := oldGroups.Difference(newGroups)
-	for _, v := range uniqueNew.List() {
-		if err := addObjectToGroup(c.conn, v.(string), objectDN); err != nil {
-			return fmt.Errorf("resourceUpdateObjectMemberOf: unable to add object to group:%s, err:%w", v.(string), err)
+
+	// groups can be referenced by dn, GUID or SID so compare resolved dns.
+	oldGroups, _, err := getObjectDNs(c, setToStringList(old.(*schema.Set)))
+	if err != nil {
+		return fmt.Errorf("resourceUpdateObjectMemberOf: %w", err)
+	}
+	newGroups, notFound, err := getObjectDNs(c, setToStringList(new.(*schema.Set)))
+	if err != nil {
+		return fmt.Errorf("resourceUpdateObjectMemberOf: %w", err)
+	}
+	if len(notFound) > 0 {
+		return fmt.Errorf("resourceUpdateObjectMemberOf: unable to find groups: %s", notFound)
+	}
+
+	// get unique value from both lists
+	uniqueNew, uniqueOld := diffDNs(oldGroups, newGroups)
+	for _, v := range uniqueNew {
+		if err := addObjectToGroup(c.conn, v, objectDN); err != nil {
+			return fmt.Errorf("resourceUpdateObjectMemberOf: unable to add object to group:%s, err:%w", v, err)
 		}
 	}
-	for _, v := range uniqueOld.List() {
-		if err := removeObjectFromGroup(c.conn, v.(string), objectDN); err != nil {
-			return fmt.Errorf("resourceUpdateObjectMemberOf: unable to remove object from group:%s, err:%w", v.(string), err)
+	for _, v := range uniqueOld {
+		if err := removeObjectFromGroup(c.conn, v, objectDN); err != nil {
+			return fmt.Errorf("resourceUpdateObjectMemberOf: unable to remove object from group:%s, err:%w", v, err)
 		}
 	}
 	return resourceReadObjectMemberOf(d, meta)
@@ -155,8 +182,14 @@ func resourceDeleteObjectMemberOf(d *schema.ResourceData, meta interface{}) erro
 	objectDN := d.Get("object_dn").(string)
 	groupDNS := d.Get("member_of").(*schema.Set)
 
-	for _, groupDN := range groupDNS.List() {
-		if err := removeObjectFromGroup(c.conn, groupDN.(string), objectDN); err != nil {
+	// groups which are already deleted doesn't need to be updated
+	groups, _, err := getObjectDNs(c, setToStringList(groupDNS))
+	if err != nil {
+		return fmt.Errorf("resourceDeleteObjectMemberOf: %w", err)
+	}
+
+	for _, groupDN := range groups {
+		if err := removeObjectFromGroup(c.conn, groupDN, objectDN); err != nil {
 			return fmt.Errorf("unable to remove object from group: %s, err:%w", groupDN, err)
 		}
 	}
@@ -182,6 +215,10 @@ func removeObjectFromGroup(conn *ldap.Conn, groupDN, objectDN string) error {
 	modReq := &ldap.ModifyRequest{DN: groupDN}
 	modReq.Delete("member", []string{objectDN})
 	if err := conn.Modify(modReq); err != nil {
+		// AD returns result Code 53 "Unwilling To Perform" if object is not a member
+		if ldap.IsErrorWithCode(err, 53) {
+			return nil
+		}
 		return err
 	}
 	return nil
@@ -0,0 +1,147 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("activedirectory_object", &resource.Sweeper{
+		Name: "activedirectory_object",
+		F: func(r string) error {
+			client, err := sharedClient()
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			c := client.(*ADClient)
+
+			err = c.initialiseConn()
+			if err != nil {
+				return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+			}
+			defer c.done()
+
+			sReq := &ldap.SearchRequest{
+				BaseDN:       c.config.topDN,
+				Scope:        ldap.ScopeWholeSubtree,
+				DerefAliases: ldap.NeverDerefAliases,
+				SizeLimit:    0,
+				TimeLimit:    0,
+				TypesOnly:    false,
+				Filter:       "(name=test_acc_object*)",
+				Attributes:   []string{"*"},
+				Controls:     nil,
+			}
+
+			sr, err := c.conn.Search(sReq)
+			if err != nil {
+				if ldap.IsErrorWithCode(err, 32) {
+					return nil
+				}
+				return err
+			}
+			var unDeleted []string
+			for _, e := range sr.Entries {
+				c.logger.Info("Sweep test deleting object...", "DN", e.DN)
+				request := ldap.DelRequest{DN: e.DN}
+				err = c.conn.Del(&request)
+				if err != nil {
+					c.logger.Error("unable to delete object", "DN", e.DN, "err", err)
+					unDeleted = append(unDeleted, e.DN)
+				}
+			}
+			if len(unDeleted) != 0 {
+				return fmt.Errorf("unable to delete object, DNs: %s", unDeleted)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccObject_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create container object
+				Config: testAccResourceADObjectTestData("test_acc_object1", "first", baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_object.test_acc_object1"),
+					resource.TestCheckResourceAttr("activedirectory_object.test_acc_object1", "rdn_attribute", "cn"),
+					resource.TestCheckResourceAttr("activedirectory_object.test_acc_object1", "object_class.0", "container"),
+					resource.TestCheckResourceAttr("activedirectory_object.test_acc_object1", "dn", "CN=test_acc_object1,"+baseOU),
+				),
+			}, {
+				// rename object and update attributes
+				Config: testAccResourceADObjectTestData("test_acc_object2", "second", baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_object.test_acc_object1"),
+					resource.TestCheckResourceAttr("activedirectory_object.test_acc_object1", "dn", "CN=test_acc_object2,"+baseOU),
+				),
+			}, {
+				ResourceName:      "activedirectory_object.test_acc_object1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// attributes which are not configured are not read
+				ImportStateVerifyIgnore: []string{"attributes"},
+			},
+		},
+	})
+}
+
+func TestAccObject_OU(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with other rdn attribute
+				Config: fmt.Sprintf(`resource "activedirectory_object" "test_acc_object3" {
+					object_class  = ["organizationalUnit"]
+					rdn_attribute = "ou"
+					name          = "test_acc_object3"
+					base_ou_dn    = "%s"
+				}`, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_object.test_acc_object3"),
+					resource.TestCheckResourceAttr("activedirectory_object.test_acc_object3", "dn", "OU=test_acc_object3,"+baseOU),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADObjectTestData(name, description, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_object" "test_acc_object1" {
+	object_class = ["container"]
+	name         = "%s"
+	base_ou_dn   = "%s"
+	attributes   = jsonencode({
+		description = ["%s"]
+	})
+}
+`, name, baseOU, description)
+}
+
+func testAccCheckObjectDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "activedirectory_object" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
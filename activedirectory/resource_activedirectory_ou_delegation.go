@@ -0,0 +1,336 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// delegationACE is an allow ACE of delegation task, object types are names of objectTypeGUIDs.
+type delegationACE struct {
+	mask                uint32
+	objectType          string
+	inheritedObjectType string
+	inheritance         string
+}
+
+// delegationTasks maps delegation tasks to ACEs they grant to the trustee on the OU. tasks of ADUC "Delegation
+// of Control" wizard use ACEs of its templates (delegwiz.inf), except join_computers which has no OU template.
+var delegationTasks = map[string][]delegationACE{
+	"create_delete_manage_users": {
+		{mask: adsRightDSCreateChild | adsRightDSDeleteChild, objectType: "user", inheritance: "all"},
+		{mask: genericRightsMapping[adsRightGenericAll], inheritedObjectType: "user", inheritance: "descendents"},
+	},
+	"reset_user_passwords": {
+		{mask: adsRightDSControlAccess, objectType: "user-force-change-password", inheritedObjectType: "user", inheritance: "descendents"},
+		{mask: adsRightDSReadProp | adsRightDSWriteProp, objectType: "pwdlastset", inheritedObjectType: "user", inheritance: "descendents"},
+	},
+	"read_user_information": {
+		{mask: adsRightDSReadProp, inheritedObjectType: "user", inheritance: "descendents"},
+	},
+	"create_delete_manage_groups": {
+		{mask: adsRightDSCreateChild | adsRightDSDeleteChild, objectType: "group", inheritance: "all"},
+		{mask: genericRightsMapping[adsRightGenericAll], inheritedObjectType: "group", inheritance: "descendents"},
+	},
+	"manage_group_membership": {
+		{mask: adsRightDSReadProp | adsRightDSWriteProp, objectType: "member", inheritedObjectType: "group", inheritance: "descendents"},
+	},
+	// wizard only grants create computer on the domain, computers pre-staged in the OU also need rights
+	// which are checked when the computer joins the domain: password reset, account restrictions and DNS host name and SPN
+	"join_computers": {
+		{mask: adsRightDSCreateChild | adsRightDSDeleteChild, objectType: "computer", inheritance: "all"},
+		{mask: adsRightDSControlAccess, objectType: "user-force-change-password", inheritedObjectType: "computer", inheritance: "descendents"},
+		{mask: adsRightDSReadProp | adsRightDSWriteProp, objectType: "user-account-restrictions", inheritedObjectType: "computer", inheritance: "descendents"},
+		{mask: adsRightDSSelf, objectType: "validated-dns-host-name", inheritedObjectType: "computer", inheritance: "descendents"},
+		{mask: adsRightDSSelf, objectType: "validated-spn", inheritedObjectType: "computer", inheritance: "descendents"},
+	},
+	"manage_gpo_links": {
+		{mask: adsRightDSReadProp | adsRightDSWriteProp, objectType: "gplink", inheritance: "all"},
+		{mask: adsRightDSReadProp | adsRightDSWriteProp, objectType: "gpoptions", inheritance: "all"},
+	},
+}
+
+// delegationTaskNames returns sorted names of delegation tasks.
+func delegationTaskNames() []string {
+	var names []string
+	for name := range delegationTasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// delegationACEs returns ACEs which grant given tasks to the trustee SID, ACEs shared by tasks are returned once.
+func delegationACEs(tasks []string, sid string) []ace {
+	var aces []ace
+	for _, task := range tasks {
+		for _, t := range delegationTasks[task] {
+			n := newACE(false, t.mask, objectTypeGUIDs[t.objectType], objectTypeGUIDs[t.inheritedObjectType], sid, t.inheritance)
+			duplicate := false
+			for _, a := range aces {
+				if a.equal(n) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				aces = append(aces, n)
+			}
+		}
+	}
+	return aces
+}
+
+// delegatedTasks returns tasks whose all ACEs for the trustee SID are explicit ACEs of the DACL.
+func delegatedTasks(l *acl, sid string) []string {
+	var tasks []string
+	if l == nil {
+		return tasks
+	}
+	for _, task := range delegationTaskNames() {
+		granted := true
+		for _, n := range delegationACEs([]string{task}, sid) {
+			if !l.hasACE(func(a ace) bool { return !a.isInherited() && a.equal(n) }) {
+				granted = false
+				break
+			}
+		}
+		if granted {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+func resourceActivedirectoryOUDelegation() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ou_dn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The dn of the OU where control is delegated",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateDN,
+			},
+			"trustee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The dn or SID of the security principal to delegate control to",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateDNOrSID,
+			},
+			"tasks": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The delegated tasks ie 'reset_user_passwords' or 'manage_group_membership'",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(delegationTaskNames(), false),
+				},
+			},
+		},
+		Create: resourceCreateOUDelegation,
+		Read:   resourceReadOUDelegation,
+		Update: resourceUpdateOUDelegation,
+		Delete: resourceDeleteOUDelegation,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// parseOUDelegationID returns OU GUID and trustee SID of OU delegation resource ID, ID is '<OU GUID>/<trustee SID>'.
+func parseOUDelegationID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || !isGUIDString(parts[0]) || !isSIDString(parts[1]) {
+		return "", "", fmt.Errorf("ID should be in format '<OU GUID>/<trustee SID>', got: %s", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceCreateOUDelegation(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateOUDelegation: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	e, err := getObjectByDN(c.conn, d.Get("ou_dn").(string))
+	if err != nil {
+		return fmt.Errorf("resourceCreateOUDelegation: unable to search ou with dn:%v err:%w", d.Get("ou_dn").(string), err)
+	}
+	guid, err := decodeGUID(e.GetRawAttributeValue("objectGUID"))
+	if err != nil {
+		return fmt.Errorf("resourceCreateOUDelegation: unable to convert raw GUID of ou to string err:%w", err)
+	}
+	trustee := d.Get("trustee").(string)
+	sids, err := getObjectSIDs(c, []string{trustee})
+	if err != nil {
+		return fmt.Errorf("resourceCreateOUDelegation: unable to get SID of trustee err:%w", err)
+	}
+
+	tasks := setToStringList(d.Get("tasks").(*schema.Set))
+	if err := updateOUDelegation(c, e.DN, sids[trustee], nil, tasks); err != nil {
+		return fmt.Errorf("resourceCreateOUDelegation: %w", err)
+	}
+
+	d.SetId(guid + "/" + sids[trustee])
+	return resourceReadOUDelegation(d, meta)
+}
+
+func resourceReadOUDelegation(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadOUDelegation: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	guid, sid, err := parseOUDelegationID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadOUDelegation: %w", err)
+	}
+	id, err := encodeGUID(guid)
+	if err != nil {
+		return fmt.Errorf("resourceReadOUDelegation: unable to encode GUID:%v err:%w", guid, err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadOUDelegation: ou not found", "GUID", guid)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadOUDelegation: unable to search ou with ID GUID:%v err:%w", guid, err)
+	}
+
+	sd, err := getObjectSecurityDescriptor(c.conn, e.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadOUDelegation: unable to read security descriptor of ou:%s err:%w", e.DN, err)
+	}
+
+	// only configured tasks are tracked so tasks delegated by other tools don't show any changes,
+	// all delegated tasks of the trustee are stored on import.
+	granted := delegatedTasks(sd.dacl, sid)
+	configured := d.Get("tasks").(*schema.Set)
+	tasks := granted
+	if configured.Len() > 0 {
+		tasks = nil
+		for _, task := range granted {
+			if configured.Contains(task) {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	if err := d.Set("tasks", stringListToInterfaces(tasks)); err != nil {
+		return fmt.Errorf("resourceReadOUDelegation: unable to update 'tasks' argument value:%v err:%w", tasks, err)
+	}
+	if d.Get("trustee").(string) == "" {
+		if err := d.Set("trustee", sid); err != nil {
+			return fmt.Errorf("resourceReadOUDelegation: unable to update 'trustee' argument value:%v err:%w", sid, err)
+		}
+	}
+	if err := d.Set("ou_dn", e.DN); err != nil {
+		return fmt.Errorf("resourceReadOUDelegation: unable to update 'ou_dn' argument value:%v err:%w", e.DN, err)
+	}
+	return nil
+}
+
+func resourceUpdateOUDelegation(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdateOUDelegation: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	_, sid, err := parseOUDelegationID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceUpdateOUDelegation: %w", err)
+	}
+	if d.HasChange("tasks") {
+		old, new := d.GetChange("tasks")
+		if err := updateOUDelegation(c, d.Get("ou_dn").(string), sid, setToStringList(old.(*schema.Set)), setToStringList(new.(*schema.Set))); err != nil {
+			return fmt.Errorf("resourceUpdateOUDelegation: %w", err)
+		}
+	}
+	return resourceReadOUDelegation(d, meta)
+}
+
+func resourceDeleteOUDelegation(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceDeleteOUDelegation: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	guid, sid, err := parseOUDelegationID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceDeleteOUDelegation: %w", err)
+	}
+	id, err := encodeGUID(guid)
+	if err != nil {
+		return fmt.Errorf("resourceDeleteOUDelegation: unable to encode GUID:%v err:%w", guid, err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return fmt.Errorf("resourceDeleteOUDelegation: unable to search ou with ID GUID:%v err:%w", guid, err)
+	}
+
+	if err := updateOUDelegation(c, e.DN, sid, setToStringList(d.Get("tasks").(*schema.Set)), nil); err != nil {
+		return fmt.Errorf("resourceDeleteOUDelegation: %w", err)
+	}
+	return nil
+}
+
+// updateOUDelegation replaces ACEs of old tasks of the trustee with ACEs of new tasks in DACL of the OU,
+// ACEs which are granted by both old and new tasks are kept.
+func updateOUDelegation(c *ADClient, dn, sid string, old, new []string) error {
+	added := delegationACEs(new, sid)
+	previous := delegationACEs(old, sid)
+	changed := false
+	err := updateObjectSecurityDescriptor(c.conn, dn, func(sd *securityDescriptor) (bool, error) {
+		if sd.dacl == nil {
+			return false, fmt.Errorf("ou:%s doesn't have DACL", dn)
+		}
+		removed := sd.dacl.removeACEs(func(a ace) bool {
+			if a.isInherited() {
+				return false
+			}
+			for _, n := range added {
+				if a.equal(n) {
+					return false
+				}
+			}
+			for _, o := range previous {
+				if a.equal(o) {
+					return true
+				}
+			}
+			return false
+		})
+		count := len(sd.dacl.aces)
+		for _, n := range added {
+			sd.dacl.addACE(n)
+		}
+		changed = removed > 0 || count != len(sd.dacl.aces)
+		return changed, nil
+	})
+	if err != nil || !changed {
+		return err
+	}
+	c.logger.Info("updateOUDelegation: delegated tasks updated", "dn", dn, "trustee", sid, "tasks", new)
+	return nil
+}
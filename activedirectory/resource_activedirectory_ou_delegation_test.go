@@ -0,0 +1,176 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccOUDelegation_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOUDelegationDestroy,
+		Steps: []resource.TestStep{
+			{
+				// delegate password reset to group
+				Config: testAccResourceADOUDelegationTestData(baseOU, `["reset_user_passwords"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOUDelegationRemote("activedirectory_ou_delegation.test_acc_delegation", []string{"reset_user_passwords"}),
+					resource.TestCheckResourceAttr("activedirectory_ou_delegation.test_acc_delegation", "ou_dn", "OU=test_acc_ou_delegation,"+baseOU),
+					resource.TestCheckResourceAttr("activedirectory_ou_delegation.test_acc_delegation", "tasks.#", "1"),
+				),
+			}, {
+				// replace delegated tasks
+				Config: testAccResourceADOUDelegationTestData(baseOU, `["manage_group_membership", "join_computers"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOUDelegationRemote("activedirectory_ou_delegation.test_acc_delegation", []string{"join_computers", "manage_group_membership"}),
+					resource.TestCheckResourceAttr("activedirectory_ou_delegation.test_acc_delegation", "tasks.#", "2"),
+				),
+			}, {
+				ResourceName:      "activedirectory_ou_delegation.test_acc_delegation",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// imported trustee is SID
+				ImportStateVerifyIgnore: []string{"trustee"},
+			},
+		},
+	})
+}
+
+// TestAccOUDelegation_MultipleTrustees delegates tasks on one ou to two trustees in a single apply
+func TestAccOUDelegation_MultipleTrustees(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOUDelegationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceADOUDelegationMultipleTestData(baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOUDelegationRemote("activedirectory_ou_delegation.test_acc_delegation_1", []string{"reset_user_passwords"}),
+					testAccCheckOUDelegationRemote("activedirectory_ou_delegation.test_acc_delegation_2", []string{"join_computers", "manage_group_membership"}),
+				),
+			},
+		},
+	})
+}
+
+// also create ou and group resources to delegate control
+func testAccResourceADOUDelegationTestData(baseOU, tasks string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_ou" "test_acc_ou_delegation" {
+	name       = "test_acc_ou_delegation"
+	base_ou_dn = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_delegation" {
+	name             = "test_acc_group_delegation"
+	sam_account_name = "test_acc_group_delegation"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_ou_delegation" "test_acc_delegation" {
+	ou_dn   = activedirectory_ou.test_acc_ou_delegation.dn
+	trustee = activedirectory_group.test_acc_group_delegation.dn
+	tasks   = %s
+}
+`, baseOU, baseOU, tasks)
+}
+
+// two groups with different tasks on the same ou
+func testAccResourceADOUDelegationMultipleTestData(baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_ou" "test_acc_ou_delegation_multi" {
+	name       = "test_acc_ou_delegation_multi"
+	base_ou_dn = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_delegation_1" {
+	name             = "test_acc_group_delegation_1"
+	sam_account_name = "test_acc_group_delegation_1"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_delegation_2" {
+	name             = "test_acc_group_delegation_2"
+	sam_account_name = "test_acc_group_delegation_2"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_ou_delegation" "test_acc_delegation_1" {
+	ou_dn   = activedirectory_ou.test_acc_ou_delegation_multi.dn
+	trustee = activedirectory_group.test_acc_group_delegation_1.dn
+	tasks   = ["reset_user_passwords"]
+}
+
+resource "activedirectory_ou_delegation" "test_acc_delegation_2" {
+	ou_dn   = activedirectory_ou.test_acc_ou_delegation_multi.dn
+	trustee = activedirectory_group.test_acc_group_delegation_2.dn
+	tasks   = ["manage_group_membership", "join_computers"]
+}
+`, baseOU, baseOU, baseOU)
+}
+
+func testAccCheckOUDelegationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "activedirectory_ou_delegation" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testAccCheckOUDelegationRemote checks only given tasks are delegated to the trustee on remote ou
+func testAccCheckOUDelegationRemote(resource string, want []string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		c := testAccProvider.Meta().(*ADClient)
+		err := c.initialiseConn()
+		if err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		guid, sid, err := parseOUDelegationID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		id, err := encodeGUID(guid)
+		if err != nil {
+			return err
+		}
+		e, err := getObjectByID(c, id)
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				return fmt.Errorf("ou of resource %s not found", resource)
+			}
+			return err
+		}
+		sd, err := getObjectSecurityDescriptor(c.conn, e.DN)
+		if err != nil {
+			return err
+		}
+		tasks := delegatedTasks(sd.dacl, sid)
+		if fmt.Sprint(tasks) != fmt.Sprint(want) {
+			return fmt.Errorf("ou %s has delegated tasks %v, want %v", e.DN, tasks, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,346 @@
+package activedirectory
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// passwordSettingsContainer is the container of fine-grained password policies (PSO) under domain dn
+const passwordSettingsContainer = "cn=password settings container,cn=system"
+
+// passwordSettingsIntAttributes maps integer arguments of password settings resource to msDS-PasswordSettings attributes
+var passwordSettingsIntAttributes = map[string]string{
+	"precedence":              "msDS-PasswordSettingsPrecedence",
+	"min_password_length":     "msDS-MinimumPasswordLength",
+	"password_history_length": "msDS-PasswordHistoryLength",
+	"lockout_threshold":       "msDS-LockoutThreshold",
+}
+
+// passwordSettingsBoolAttributes maps boolean arguments of password settings resource to msDS-PasswordSettings attributes
+var passwordSettingsBoolAttributes = map[string]string{
+	"complexity_enabled":            "msDS-PasswordComplexityEnabled",
+	"reversible_encryption_enabled": "msDS-PasswordReversibleEncryptionEnabled",
+}
+
+// passwordSettingsDurationAttributes maps duration arguments of password settings resource to msDS-PasswordSettings attributes
+var passwordSettingsDurationAttributes = map[string]string{
+	"min_password_age":           "msDS-MinimumPasswordAge",
+	"max_password_age":           "msDS-MaximumPasswordAge",
+	"lockout_duration":           "msDS-LockoutDuration",
+	"lockout_observation_window": "msDS-LockoutObservationWindow",
+}
+
+func resourceActivedirectoryPasswordSettings() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the Object, password settings object is created in 'CN=Password Settings Container,CN=System' of the domain",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"precedence": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "The precedence of the password settings, settings with lower value wins if multiple settings apply to a user",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"min_password_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      7,
+				Description:  "The minimum length of passwords",
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+			"password_history_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      24,
+				Description:  "The number of old passwords which can't be reused",
+				ValidateFunc: validation.IntBetween(0, 1024),
+			},
+			"complexity_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "The passwords must meet complexity requirements",
+			},
+			"reversible_encryption_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "The passwords are stored using reversible encryption",
+			},
+			"min_password_age": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "24h",
+				Description:      "The minimum age of password before it can be changed, ie '24h'",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"max_password_age": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "1008h",
+				Description:      "The maximum age of password before it expires, ie '1008h' or 'never'",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"lockout_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The number of failed logon attempts which locks out the account, 0 means account is never locked out",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"lockout_duration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "30m",
+				Description:      "The duration of account lockout, ie '30m' or 'never' for lockout until administrator unlocks the account",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"lockout_observation_window": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "30m",
+				Description:      "The duration after which failed logon attempts counter is reset, ie '30m'",
+				DiffSuppressFunc: durationDiffSuppressor,
+				ValidateFunc:     validateDuration,
+			},
+			"applies_to": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The dn of users and global security groups the password settings apply to (msDS-PSOAppliesTo)",
+				Set:         lowercaseHashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateDN,
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the AD object",
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the object",
+			},
+		},
+		Create: resourceCreatePasswordSettings,
+		Read:   resourceReadPasswordSettings,
+		Update: resourceUpdatePasswordSettings,
+		Delete: resourceDeleteObject,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCreatePasswordSettings(d *schema.ResourceData, meta interface{}) error {
+	var err error
+	c := meta.(*ADClient)
+	err = c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreatePasswordSettings: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	addReq, err := passwordSettingsSchemaToAddRequest(d, c.config.domainDN)
+	if err != nil {
+		return fmt.Errorf("resourceCreatePasswordSettings: unable to create add request err: %w", err)
+	}
+	c.logger.Debug("resourceCreatePasswordSettings: ldap add request", "addReq", addReq)
+
+	guid, err := addObject(c.conn, addReq)
+	if err != nil {
+		return fmt.Errorf("resourceCreatePasswordSettings: unable to create password settings err: %w", err)
+	}
+	c.logger.Info("resourceCreatePasswordSettings: password settings added to active directory", "guid", guid)
+	d.SetId(guid)
+	return resourceReadPasswordSettings(d, meta)
+}
+
+func resourceReadPasswordSettings(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadPasswordSettings: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadPasswordSettings: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadPasswordSettings: object not found", "GUID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadPasswordSettings: unable to search password settings with ID  GUID:%v err:%w", d.Id(), err)
+	}
+	c.logger.Info("resourceReadPasswordSettings: password settings object found", "dn", e.DN)
+
+	if err := updateObjectSchema(resourceActivedirectoryPasswordSettings().Schema, e, d); err != nil {
+		return err
+	}
+
+	for arg, attr := range passwordSettingsIntAttributes {
+		rv := e.GetAttributeValue(attr)
+		v, err := strconv.Atoi(rv)
+		if err != nil {
+			return fmt.Errorf("resourceReadPasswordSettings: unable to parse '%s' value:%v err:%w", attr, rv, err)
+		}
+		if err := d.Set(arg, v); err != nil {
+			return fmt.Errorf("resourceReadPasswordSettings: unable to update '%s' argument value:%v err:%w", arg, v, err)
+		}
+	}
+	for arg, attr := range passwordSettingsBoolAttributes {
+		v := strings.EqualFold(e.GetAttributeValue(attr), "TRUE")
+		if err := d.Set(arg, v); err != nil {
+			return fmt.Errorf("resourceReadPasswordSettings: unable to update '%s' argument value:%v err:%w", arg, v, err)
+		}
+	}
+	for arg, attr := range passwordSettingsDurationAttributes {
+		v, err := intervalToDuration(e.GetAttributeValue(attr))
+		if err != nil {
+			return fmt.Errorf("resourceReadPasswordSettings: unable to convert '%s' value err:%w", attr, err)
+		}
+		if err := d.Set(arg, v); err != nil {
+			return fmt.Errorf("resourceReadPasswordSettings: unable to update '%s' argument value:%v err:%w", arg, v, err)
+		}
+	}
+
+	appliesTo := e.GetAttributeValues("msDS-PSOAppliesTo")
+	if err := d.Set("applies_to", schema.NewSet(lowercaseHashString, stringListToInterfaces(appliesTo))); err != nil {
+		return fmt.Errorf("resourceReadPasswordSettings: unable to update 'applies_to' argument value:%v err:%w", appliesTo, err)
+	}
+	return nil
+}
+
+func resourceUpdatePasswordSettings(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdatePasswordSettings: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	container := passwordSettingsContainer + "," + c.config.domainDN
+
+	// check if name is changed, password settings object can't be moved out of the container
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+		c.logger.Debug("resourceUpdatePasswordSettings: Name Changes", "old", oldName, "new", newName)
+
+		req := &ldap.ModifyDNRequest{
+			DN:           "cn=" + oldName.(string) + "," + container,
+			NewRDN:       "cn=" + newName.(string),
+			DeleteOldRDN: true,
+		}
+		if err = c.conn.ModifyDN(req); err != nil {
+			return fmt.Errorf("resourceUpdatePasswordSettings: unable to update dn of LDAP object: ModifyDNRequest:%v err:%w", req, err)
+		}
+		c.logger.Info("resourceUpdatePasswordSettings: password settings DN modified", "NewRDN", req.NewRDN)
+	}
+
+	modReq := &ldap.ModifyRequest{DN: "cn=" + d.Get("name").(string) + "," + container}
+
+	for arg, attr := range passwordSettingsIntAttributes {
+		if d.HasChange(arg) {
+			modReq.Replace(attr, []string{strconv.Itoa(d.Get(arg).(int))})
+			c.logger.Debug("resourceUpdatePasswordSettings: updating '"+arg+"'", "new", d.Get(arg).(int))
+		}
+	}
+	for arg, attr := range passwordSettingsBoolAttributes {
+		if d.HasChange(arg) {
+			modReq.Replace(attr, []string{strings.ToUpper(strconv.FormatBool(d.Get(arg).(bool)))})
+			c.logger.Debug("resourceUpdatePasswordSettings: updating '"+arg+"'", "new", d.Get(arg).(bool))
+		}
+	}
+	for arg, attr := range passwordSettingsDurationAttributes {
+		if d.HasChange(arg) {
+			v, err := durationToInterval(d.Get(arg).(string))
+			if err != nil {
+				return fmt.Errorf("resourceUpdatePasswordSettings: unable to convert '%s' err:%w", arg, err)
+			}
+			modReq.Replace(attr, []string{v})
+			c.logger.Debug("resourceUpdatePasswordSettings: updating '"+arg+"'", "new", d.Get(arg).(string))
+		}
+	}
+
+	if d.HasChange("applies_to") {
+		appliesTo := setToStringList(d.Get("applies_to").(*schema.Set))
+		modReq.Replace("msDS-PSOAppliesTo", appliesTo)
+		c.logger.Debug("resourceUpdatePasswordSettings: updating 'applies_to'", "new", appliesTo)
+	}
+
+	if d.HasChange("description") {
+		if d.Get("description").(string) == "" {
+			modReq.Replace("description", []string{})
+		} else {
+			modReq.Replace("description", []string{d.Get("description").(string)})
+		}
+		c.logger.Debug("resourceUpdatePasswordSettings: updating 'description'", "new", d.Get("description").(string))
+	}
+
+	if len(modReq.Changes) > 0 {
+		if err = c.conn.Modify(modReq); err != nil {
+			return fmt.Errorf("resourceUpdatePasswordSettings: unable to update some attributes of LDAP object: ModifyRequest:%#v err:%w", modReq, err)
+		}
+		c.logger.Info("resourceUpdatePasswordSettings: modified", "dn", modReq.DN)
+	}
+	return resourceReadPasswordSettings(d, meta)
+}
+
+func passwordSettingsSchemaToAddRequest(d *schema.ResourceData, domainDN string) (*ldap.AddRequest, error) {
+	var addReq ldap.AddRequest
+
+	name := d.Get("name").(string)
+	addReq.DN = "cn=" + name + "," + passwordSettingsContainer + "," + domainDN
+
+	addReq.Attribute("objectClass", []string{"msDS-PasswordSettings"})
+	addReq.Attribute("name", []string{name})
+	addReq.Attribute("cn", []string{name})
+
+	// all password settings attributes are mandatory for msDS-PasswordSettings class
+	for arg, attr := range passwordSettingsIntAttributes {
+		addReq.Attribute(attr, []string{strconv.Itoa(d.Get(arg).(int))})
+	}
+	for arg, attr := range passwordSettingsBoolAttributes {
+		addReq.Attribute(attr, []string{strings.ToUpper(strconv.FormatBool(d.Get(arg).(bool)))})
+	}
+	for arg, attr := range passwordSettingsDurationAttributes {
+		v, err := durationToInterval(d.Get(arg).(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert '%s' err:%w", arg, err)
+		}
+		addReq.Attribute(attr, []string{v})
+	}
+
+	if appliesTo := setToStringList(d.Get("applies_to").(*schema.Set)); len(appliesTo) > 0 {
+		addReq.Attribute("msDS-PSOAppliesTo", appliesTo)
+	}
+	if d.Get("description").(string) != "" {
+		addReq.Attribute("description", []string{d.Get("description").(string)})
+	}
+
+	return &addReq, nil
+}
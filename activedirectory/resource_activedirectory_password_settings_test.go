@@ -0,0 +1,158 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("activedirectory_password_settings", &resource.Sweeper{
+		Name: "activedirectory_password_settings",
+		F: func(r string) error {
+			client, err := sharedClient()
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			c := client.(*ADClient)
+
+			err = c.initialiseConn()
+			if err != nil {
+				return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+			}
+			defer c.done()
+
+			sReq := &ldap.SearchRequest{
+				BaseDN:       passwordSettingsContainer + "," + c.config.domainDN,
+				Scope:        ldap.ScopeWholeSubtree,
+				DerefAliases: ldap.NeverDerefAliases,
+				SizeLimit:    0,
+				TimeLimit:    0,
+				TypesOnly:    false,
+				Filter:       "(&(objectClass=msDS-PasswordSettings)(cn=test_acc_pso*))",
+				Attributes:   []string{"*"},
+				Controls:     nil,
+			}
+
+			sr, err := c.conn.Search(sReq)
+			if err != nil {
+				if ldap.IsErrorWithCode(err, 32) {
+					return nil
+				}
+				return err
+			}
+			var unDeleted []string
+			for _, e := range sr.Entries {
+				c.logger.Info("Sweep test deleting object...", "DN", e.DN)
+				request := ldap.DelRequest{DN: e.DN}
+				err = c.conn.Del(&request)
+				if err != nil {
+					c.logger.Error("unable to delete object", "DN", e.DN, "err", err)
+					unDeleted = append(unDeleted, e.DN)
+				}
+			}
+			if len(unDeleted) != 0 {
+				return fmt.Errorf("unable to delete object, DNs: %s", unDeleted)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccPasswordSettings_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPasswordSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with only required argument defined
+				Config: `resource "activedirectory_password_settings" "test_acc_pso1" {
+					name       = "test_acc_pso1"
+					precedence = 100
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_password_settings.test_acc_pso1"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso1", "min_password_length", "7"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso1", "complexity_enabled", "true"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso1", "max_password_age", "1008h0m0s"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso1", "applies_to.#", "0"),
+				),
+			}, {
+				ResourceName:      "activedirectory_password_settings.test_acc_pso1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPasswordSettings_Advanced(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPasswordSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create password settings applied to group
+				Config: testAccResourceADPasswordSettingsTestData("test_acc_pso2", 14, "never", baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_password_settings.test_acc_pso2"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "min_password_length", "14"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "lockout_threshold", "5"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "lockout_duration", "never"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "applies_to.#", "1"),
+				),
+			}, {
+				// rename and update settings
+				Config: testAccResourceADPasswordSettingsTestData("test_acc_pso3", 16, "1h", baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_password_settings.test_acc_pso2"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "name", "test_acc_pso3"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "min_password_length", "16"),
+					resource.TestCheckResourceAttr("activedirectory_password_settings.test_acc_pso2", "lockout_duration", "1h0m0s"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADPasswordSettingsTestData(name string, minLength int, lockoutDuration, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_group" "test_acc_group_pso" {
+	name             = "test_acc_group_pso"
+	sam_account_name = "test_acc_group_pso"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_password_settings" "test_acc_pso2" {
+	name                       = "%s"
+	precedence                 = 10
+	min_password_length        = %d
+	password_history_length    = 10
+	max_password_age           = "2160h"
+	lockout_threshold          = 5
+	lockout_duration           = "%s"
+	lockout_observation_window = "15m"
+	applies_to                 = [activedirectory_group.test_acc_group_pso.dn]
+	description                = "testing description"
+}
+`, baseOU, name, minLength, lockoutDuration)
+}
+
+func testAccCheckPasswordSettingsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "activedirectory_password_settings" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package activedirectory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceActivedirectorySMSA() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the Object",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"base_ou_dn": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The full path of the Organizational Unit (OU) or container where the object is created, default is 'CN=Managed Service Accounts' container of the domain",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"sam_account_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The sAMAccountName attribute is a logon name of the account, it must end with '$' and it's limited to 15 characters without '$'.",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateMSASAMAccountName,
+			},
+			"host_computer": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The dn or GUID of the computer which uses the account, it's linked by msDS-HostServiceAccount attribute of the computer",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateDNOrGUID,
+			},
+			"dns_host_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The DNS host name of the account (dNSHostName)",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+			},
+			"service_principal_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The service principal names of the account (servicePrincipalName)",
+				Set:         lowercaseHashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the AD object",
+			},
+			"dn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name (dn) of the object",
+			},
+			"attributes": {
+				Type:         schema.TypeString,
+				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
+				Optional:     true,
+				ValidateFunc: validateAttributesJSON,
+				StateFunc:    normalizeAttributesJSON,
+				Default:      "{}",
+			},
+		},
+		Create: resourceCreateSMSA,
+		Read:   resourceReadSMSA,
+		Update: resourceUpdateSMSA,
+		Delete: resourceDeleteObject,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCreateSMSA(d *schema.ResourceData, meta interface{}) error {
+	var err error
+	c := meta.(*ADClient)
+	err = c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceCreateSMSA: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	ou := d.Get("base_ou_dn").(string)
+	if ou == "" {
+		ou = defaultMSAContainer + "," + c.config.domainDN
+		if err := d.Set("base_ou_dn", ou); err != nil {
+			return fmt.Errorf("resourceCreateSMSA: unable to update 'base_ou_dn' err:%w", err)
+		}
+	} else if err := validateMSAContainer(c, ou); err != nil {
+		return fmt.Errorf("resourceCreateSMSA: base_ou_dn is not valid err: %w", err)
+	}
+
+	addReq := smsaSchemaToAddRequest(d)
+	c.logger.Debug("resourceCreateSMSA: ldap add request", "addReq", addReq)
+
+	guid, err := addObject(c.conn, addReq)
+	if err != nil {
+		return fmt.Errorf("resourceCreateSMSA: unable to create managed service account err: %w", err)
+	}
+	c.logger.Info("resourceCreateSMSA: managed service account added to active directory", "guid", guid)
+	d.SetId(guid)
+
+	if host := d.Get("host_computer").(string); host != "" {
+		if err := setHostServiceAccount(c, host, addReq.DN, true); err != nil {
+			return fmt.Errorf("resourceCreateSMSA: unable to link account to host computer err: %w", err)
+		}
+		c.logger.Info("resourceCreateSMSA: account linked to host computer", "dn", addReq.DN, "host_computer", host)
+	}
+	return resourceReadSMSA(d, meta)
+}
+
+func resourceReadSMSA(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceReadSMSA: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	id, err := encodeGUID(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceReadSMSA: unable to encode GUID:%v err:%w", d.Id(), err)
+	}
+	e, err := getObjectByID(c, id)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			c.logger.Error("resourceReadSMSA: object not found", "GUID", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceReadSMSA: unable to search managed service account with ID  GUID:%v err:%w", d.Id(), err)
+	}
+	c.logger.Info("resourceReadSMSA: managed service account object found", "dn", e.DN)
+
+	if err := updateObjectSchema(resourceActivedirectorySMSA().Schema, e, d); err != nil {
+		return err
+	}
+
+	host, err := getHostComputer(c, e.DN, d.Get("host_computer").(string))
+	if err != nil {
+		return fmt.Errorf("resourceReadSMSA: unable to get host computer err:%w", err)
+	}
+	if err := d.Set("host_computer", host); err != nil {
+		return fmt.Errorf("resourceReadSMSA: unable to update 'host_computer' argument value:%v err:%w", host, err)
+	}
+	return nil
+}
+
+func resourceUpdateSMSA(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*ADClient)
+	err := c.initialiseConn()
+	if err != nil {
+		return fmt.Errorf("resourceUpdateSMSA: unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	// check if DN is changed
+	if d.HasChanges("name", "base_ou_dn") {
+		oldName, newName := d.GetChange("name")
+		oldOU, newOU := d.GetChange("base_ou_dn")
+		if err := validateMSAContainer(c, newOU.(string)); err != nil {
+			return fmt.Errorf("resourceUpdateSMSA: new base_ou_dn is not valid err: %w", err)
+		}
+		if err := renameObject(c, "cn", oldName.(string), oldOU.(string), newName.(string), newOU.(string)); err != nil {
+			return fmt.Errorf("resourceUpdateSMSA: %w", err)
+		}
+	}
+
+	modReq := &ldap.ModifyRequest{DN: "cn=" + d.Get("name").(string) + "," + d.Get("base_ou_dn").(string)}
+
+	if d.HasChange("sam_account_name") {
+		modReq.Replace("sAMAccountName", []string{d.Get("sam_account_name").(string)})
+		c.logger.Debug("resourceUpdateSMSA: updating 'sam_account_name'", "new", d.Get("sam_account_name").(string))
+	}
+
+	if d.HasChange("dns_host_name") {
+		if d.Get("dns_host_name").(string) == "" {
+			modReq.Replace("dNSHostName", []string{})
+		} else {
+			modReq.Replace("dNSHostName", []string{d.Get("dns_host_name").(string)})
+		}
+		c.logger.Debug("resourceUpdateSMSA: updating 'dns_host_name'", "new", d.Get("dns_host_name").(string))
+	}
+
+	if d.HasChange("service_principal_names") {
+		spns := setToStringList(d.Get("service_principal_names").(*schema.Set))
+		modReq.Replace("servicePrincipalName", spns)
+		c.logger.Debug("resourceUpdateSMSA: updating 'service_principal_names'", "new", spns)
+	}
+
+	if d.HasChange("description") {
+		if d.Get("description").(string) == "" {
+			modReq.Replace("description", []string{})
+		} else {
+			modReq.Replace("description", []string{d.Get("description").(string)})
+		}
+		c.logger.Debug("resourceUpdateSMSA: updating 'description'", "new", d.Get("description").(string))
+	}
+
+	addAttributesToModifyRequest(c, d, modReq)
+
+	if len(modReq.Changes) > 0 {
+		if err = c.conn.Modify(modReq); err != nil {
+			return fmt.Errorf("resourceUpdateSMSA: unable to update some attributes of LDAP object: ModifyRequest:%#v err:%w", modReq, err)
+		}
+		c.logger.Info("resourceUpdateSMSA: modified", "dn", modReq.DN)
+	}
+
+	if d.HasChange("host_computer") {
+		oldHost, newHost := d.GetChange("host_computer")
+		// link is removed from the old computer only if it still exists
+		if oldHost.(string) != "" {
+			if err := setHostServiceAccount(c, oldHost.(string), modReq.DN, false); err != nil && !errors.Is(err, ErrObjectNotFound) {
+				return fmt.Errorf("resourceUpdateSMSA: unable to unlink account from host computer err: %w", err)
+			}
+		}
+		if newHost.(string) != "" {
+			if err := setHostServiceAccount(c, newHost.(string), modReq.DN, true); err != nil {
+				return fmt.Errorf("resourceUpdateSMSA: unable to link account to host computer err: %w", err)
+			}
+		}
+		c.logger.Info("resourceUpdateSMSA: host computer updated", "dn", modReq.DN, "old", oldHost, "new", newHost)
+	}
+	return resourceReadSMSA(d, meta)
+}
+
+func smsaSchemaToAddRequest(d *schema.ResourceData) *ldap.AddRequest {
+	var addReq ldap.AddRequest
+
+	name := d.Get("name").(string)
+	attributes := d.Get("attributes").(string)
+
+	addReq.DN = "cn=" + name + "," + d.Get("base_ou_dn").(string)
+
+	// add attributes
+	attrMap := map[string][]string{}
+	_ = json.Unmarshal([]byte(attributes), &attrMap)
+	for name, values := range attrMap {
+		addReq.Attribute(name, values)
+	}
+
+	addReq.Attribute("sAMAccountName", []string{d.Get("sam_account_name").(string)})
+	// sMSA is workstation trust account
+	addReq.Attribute("userAccountControl", []string{"4096"})
+	addReq.Attribute("objectClass", []string{"msDS-ManagedServiceAccount"})
+	addReq.Attribute("name", []string{name})
+	addReq.Attribute("cn", []string{name})
+	if d.Get("dns_host_name").(string) != "" {
+		addReq.Attribute("dNSHostName", []string{d.Get("dns_host_name").(string)})
+	}
+	if spns := setToStringList(d.Get("service_principal_names").(*schema.Set)); len(spns) > 0 {
+		addReq.Attribute("servicePrincipalName", spns)
+	}
+	if d.Get("description").(string) != "" {
+		addReq.Attribute("description", []string{d.Get("description").(string)})
+	}
+
+	return &addReq
+}
@@ -0,0 +1,158 @@
+package activedirectory
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("activedirectory_smsa", &resource.Sweeper{
+		Name: "activedirectory_smsa",
+		F: func(r string) error {
+			client, err := sharedClient()
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			c := client.(*ADClient)
+
+			err = c.initialiseConn()
+			if err != nil {
+				return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+			}
+			defer c.done()
+			entries, err := getObjectsBySAM(c, "test_acc_smsa*")
+			if err != nil {
+				return err
+			}
+			var unDeleted []string
+			for _, e := range entries {
+				c.logger.Info("Sweep test deleting object...", "DN", e.DN)
+				request := ldap.DelRequest{DN: e.DN}
+				err = c.conn.Del(&request)
+				if err != nil {
+					c.logger.Error("unable to delete object", "DN", e.DN, "err", err)
+					unDeleted = append(unDeleted, e.DN)
+				}
+			}
+			if len(unDeleted) != 0 {
+				return fmt.Errorf("unable to delete object, DNs: %s", unDeleted)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccSMSA_Basic(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSMSADestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object linked to computer by dn
+				Config: testAccResourceADSMSATestData("activedirectory_computer.test_acc_comp_smsa1.dn", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_smsa.test_acc_smsa1"),
+					resource.TestCheckResourceAttr("activedirectory_smsa.test_acc_smsa1", "sam_account_name", "test_acc_smsa1$"),
+					resource.TestCheckResourceAttr("activedirectory_smsa.test_acc_smsa1", "base_ou_dn", baseOU),
+					resource.TestCheckResourceAttr("activedirectory_smsa.test_acc_smsa1", "service_principal_names.#", "1"),
+					resource.TestCheckResourceAttrPair("activedirectory_smsa.test_acc_smsa1", "host_computer", "activedirectory_computer.test_acc_comp_smsa1", "dn"),
+				),
+			}, {
+				// link account to other computer using GUID
+				Config: testAccResourceADSMSATestData("activedirectory_computer.test_acc_comp_smsa2.guid", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_smsa.test_acc_smsa1"),
+					resource.TestCheckResourceAttrPair("activedirectory_smsa.test_acc_smsa1", "host_computer", "activedirectory_computer.test_acc_comp_smsa2", "guid"),
+				),
+			}, {
+				// unlink account
+				Config: testAccResourceADSMSATestData(`""`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_smsa.test_acc_smsa1"),
+					resource.TestCheckResourceAttr("activedirectory_smsa.test_acc_smsa1", "host_computer", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSMSA_DefaultContainer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSMSADestroy,
+		Steps: []resource.TestStep{
+			{
+				// create object with only required arguments in default container
+				Config: testAccResourceADSMSADefaultContainerTestData("test_acc_smsa2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_smsa.test_acc_smsa2"),
+					resource.TestMatchResourceAttr("activedirectory_smsa.test_acc_smsa2", "dn", regexp.MustCompile("(?i)^CN=test_acc_smsa2,CN=Managed Service Accounts,")),
+				),
+			}, {
+				// rename object in default container
+				Config: testAccResourceADSMSADefaultContainerTestData("test_acc_smsa2_renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_smsa.test_acc_smsa2"),
+					resource.TestCheckResourceAttr("activedirectory_smsa.test_acc_smsa2", "name", "test_acc_smsa2_renamed"),
+					resource.TestMatchResourceAttr("activedirectory_smsa.test_acc_smsa2", "dn", regexp.MustCompile("(?i)^CN=test_acc_smsa2_renamed,CN=Managed Service Accounts,")),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADSMSADefaultContainerTestData(name string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_smsa" "test_acc_smsa2" {
+	name             = "%s"
+	sam_account_name = "test_acc_smsa2$"
+}
+`, name)
+}
+
+func testAccResourceADSMSATestData(host, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_computer" "test_acc_comp_smsa1" {
+	name             = "test_acc_comp_smsa1"
+	sam_account_name = "test_acc_cmp_sm1$"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_computer" "test_acc_comp_smsa2" {
+	name             = "test_acc_comp_smsa2"
+	sam_account_name = "test_acc_cmp_sm2$"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_smsa" "test_acc_smsa1" {
+	name                    = "test_acc_smsa1"
+	sam_account_name        = "test_acc_smsa1$"
+	base_ou_dn              = "%s"
+	host_computer           = %s
+	dns_host_name           = "test_acc_smsa1.%s"
+	service_principal_names = ["HTTP/test_acc_smsa1.%s"]
+}
+`, baseOU, baseOU, baseOU, host, domain, domain)
+}
+
+func testAccCheckSMSADestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "activedirectory_smsa" {
+			continue
+		}
+		if err := isObjectDestroyed(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"golang.org/x/text/encoding/unicode"
 )
 
@@ -68,10 +71,53 @@ func resourceActivedirectoryUser() *schema.Resource {
 				},
 			},
 			"password": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				Description:      "The password for user object. if password_version is set, password is write-only and it's not stored in state",
+				ConflictsWith:    []string{"generate_password"},
+				DiffSuppressFunc: passwordVersionDiffSuppressor,
+			},
+			"password_version": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The version of write-only password, password is reset only when version changes",
+				ValidateFunc:  validation.IntAtLeast(1),
+				RequiredWith:  []string{"password"},
+				ConflictsWith: []string{"generate_password"},
+			},
+			"password_version_last_set": {
 				Type:        schema.TypeString,
-				Optional:    true,
+				Computed:    true,
+				Description: "The time when password of current password_version was set as RFC3339 timestamp",
+			},
+			"password_change_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      passwordChangeModeReset,
+				Description:  "The mode of password update, 'reset' replaces the password which requires reset password right, 'change' changes the password using the current password from state",
+				ValidateFunc: validation.StringInSlice([]string{passwordChangeModeReset, passwordChangeModeChange}, false),
+			},
+			"generate_password": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "If true, password is generated by provider based on domain password policy and stored in generated_password",
+				ConflictsWith: []string{"password"},
+			},
+			"generated_password": {
+				Type:        schema.TypeString,
+				Computed:    true,
 				Sensitive:   true,
-				Description: "The password for user object.",
+				Description: "The password generated by provider when generate_password is true",
+			},
+			"password_rotation_trigger": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The arbitrary map of values, when changed password is regenerated and reset if generate_password is true",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -88,6 +134,181 @@ func resourceActivedirectoryUser() *schema.Resource {
 				Optional:    true,
 				Description: "A lastname/sn of the user object",
 			},
+			"mail": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The email address of the user",
+				ValidateFunc: validation.StringMatch(upnStringRegexp, "mail should be in format 'someone@example.com'"),
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The display name of the user",
+			},
+			"initials": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The initials of the user, max 6 characters",
+				ValidateFunc: validation.StringLenBetween(0, 6),
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The job title of the user",
+			},
+			"department": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The department of the user",
+			},
+			"company": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The company of the user",
+			},
+			"office": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The office location of the user (physicalDeliveryOfficeName)",
+			},
+			"telephone_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The primary telephone number of the user",
+			},
+			"mobile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The mobile phone number of the user",
+			},
+			"employee_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The employee ID of the user",
+			},
+			"employee_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The employee type of the user, ie 'Employee' or 'Contractor'",
+			},
+			"street": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The street address of the user",
+			},
+			"city": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The city (l) of the user",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The state or province (st) of the user",
+			},
+			"postal_code": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The postal code of the user",
+			},
+			"country": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ISO 3166-1 alpha-2 country code of the user, c, co and countryCode attributes are updated",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateCountryCode,
+			},
+			"manager": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The dn or GUID of the user's manager",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validateDNOrGUID,
+			},
+			"home_page": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The home page (wWWHomePage) of the user",
+			},
+			"home_directory": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The UNC path of the home directory of the user",
+				ValidateFunc: validateUNCPath,
+			},
+			"home_drive": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The drive letter to which home directory is mapped ie 'H:', requires home_directory",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validation.StringMatch(driveRegexp, "home_drive should be a drive letter followed by colon ie 'H:'"),
+			},
+			"profile_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The UNC path of the roaming profile of the user",
+				ValidateFunc: validateUNCPath,
+			},
+			"script_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The path of the logon script of the user, relative to NETLOGON share or UNC path",
+				ValidateFunc: validateScriptPath,
+			},
+			"user_workstations": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The NetBIOS names of computers the user is allowed to log on to",
+				Set:         lowercaseHashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateWorkstationName,
+				},
+			},
+			"logon_hours": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The weekly schedule of hours when user is allowed to log on, if not set user can log on at any time",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timezone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							Description:  "The IANA time zone name or UTC offset in whole hours ie '+02:00' of the schedule",
+							ValidateFunc: validateLogonHoursTimezone,
+						},
+						"schedule": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The hour ranges of week days when logon is allowed, user can't log on at all if not set",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"day": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "The day of the week in lowercase ie 'monday'",
+										ValidateFunc: validation.StringInSlice(weekdays, false),
+									},
+									"start": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "The first hour of the range",
+										ValidateFunc: validation.IntBetween(0, 23),
+									},
+									"end": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "The end hour of the range, exclusive",
+										ValidateFunc: validation.IntBetween(1, 24),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"user_account_control": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -112,6 +333,110 @@ func resourceActivedirectoryUser() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"password_never_expires": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The password of the object never expires (DONT_EXPIRE_PASSWORD flag)",
+			},
+			"password_not_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is not required to have a password (PASSWD_NOTREQD flag)",
+			},
+			"cannot_change_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object cannot change its own password, managed by deny ACEs of 'Change Password' extended right",
+			},
+			"smartcard_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The smart card is required for interactive logon (SMARTCARD_REQUIRED flag)",
+			},
+			"trusted_for_delegation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is trusted for Kerberos delegation (TRUSTED_FOR_DELEGATION flag)",
+			},
+			"trusted_to_auth_for_delegation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is trusted to authenticate for delegation (TRUSTED_TO_AUTH_FOR_DELEGATION flag)",
+			},
+			"not_delegated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The security context of the object is not delegated (NOT_DELEGATED flag)",
+			},
+			"use_des_key_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object is restricted to use only DES encryption types for keys (USE_DES_KEY_ONLY flag)",
+			},
+			"dont_require_preauth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The object doesn't require Kerberos pre-authentication (DONT_REQ_PREAUTH flag)",
+			},
+			"account_expires": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The expiry time of the account as RFC3339 timestamp or 'never'",
+				DiffSuppressFunc: accountExpiresDiffSuppressor,
+				ValidateFunc:     validateAccountExpires,
+			},
+			"change_password_at_next_logon": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The user must change password at next logon, pwdLastSet is set to 0",
+			},
+			"password_last_set": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time when password was last set as RFC3339 timestamp",
+			},
+			"password_expiry_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time when password expires as RFC3339 timestamp or 'never'",
+			},
+			"last_logon": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last logon replicated to all domain controllers (lastLogonTimestamp) as RFC3339 timestamp",
+			},
+			"locked_out": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The lockout status of the account, based on lockoutTime and domain lockout duration",
+			},
+			"unlock_trigger": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The arbitrary map of values, when changed account is unlocked once by setting lockoutTime to 0",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"primary_group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The dn or SID of the primary group of the object, object is also added to the group",
+				DiffSuppressFunc: ignoreCaseDiffSuppressor,
+				ValidateFunc:     validatePrimaryGroup,
+			},
 			"attributes": {
 				Type:         schema.TypeString,
 				Description:  `The list of other attributes of object, represented in json as map with 'attribute name' as key and values as array of string ie '{attribute_name = ["value1","value2"]}'`,
@@ -121,10 +446,11 @@ func resourceActivedirectoryUser() *schema.Resource {
 				Default:      "{}",
 			},
 		},
-		Create: resourceCreateUser,
-		Read:   resourceReadUser,
-		Update: resourceUpdateUser,
-		Delete: resourceDeleteObject,
+		Create:        resourceCreateUser,
+		Read:          resourceReadUser,
+		Update:        resourceUpdateUser,
+		Delete:        resourceDeleteObject,
+		CustomizeDiff: resourceCustomizeDiffUser,
 		// Exists: resourceExistsObject,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -147,10 +473,33 @@ func resourceCreateUser(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("resourceCreateUser: base_ou_dn is not valid err: %w", err)
 	}
 
+	if userPassword(d) != "" || d.Get("generate_password").(bool) {
+		if err := c.requireConfidentialConn(); err != nil {
+			return fmt.Errorf("resourceCreateUser: unable to set user password err: %w", err)
+		}
+	}
+
+	if d.Get("generate_password").(bool) {
+		pwd, err := generateUserPassword(c, d)
+		if err != nil {
+			return fmt.Errorf("resourceCreateUser: unable to generate password err: %w", err)
+		}
+		if err := d.Set("generated_password", pwd); err != nil {
+			return fmt.Errorf("resourceCreateUser: unable to update 'generated_password' err:%w", err)
+		}
+	}
+
 	addReq, err := userSchemaToAddRequest(d)
 	if err != nil {
 		return fmt.Errorf("resourceCreateUser: unable to convert schema to addrequest err:%w", err)
 	}
+	if m := d.Get("manager").(string); m != "" {
+		managerDN, err := getObjectDN(c, m)
+		if err != nil {
+			return fmt.Errorf("resourceCreateUser: unable to find manager:%s err:%w", m, err)
+		}
+		addReq.Attribute("manager", []string{managerDN})
+	}
 	c.logger.Debug("resourceCreateUser: ldap add request", "addReq", addReq)
 
 	guid, err := addObject(c.conn, addReq)
@@ -159,6 +508,20 @@ func resourceCreateUser(d *schema.ResourceData, meta interface{}) error {
 	}
 	c.logger.Info("resourceCreateUser: user added to active directory", "guid", guid)
 	d.SetId(guid)
+
+	if d.Get("cannot_change_password").(bool) {
+		if err := setCannotChangePassword(c.conn, addReq.DN, true); err != nil {
+			return fmt.Errorf("resourceCreateUser: unable to set 'cannot_change_password' err: %w", err)
+		}
+		c.logger.Info("resourceCreateUser: change password denied", "dn", addReq.DN)
+	}
+
+	if pg := d.Get("primary_group").(string); pg != "" {
+		if err := setPrimaryGroup(c, addReq.DN, pg); err != nil {
+			return fmt.Errorf("resourceCreateUser: unable to set primary group err: %w", err)
+		}
+		c.logger.Info("resourceCreateUser: primary group set", "dn", addReq.DN, "primary_group", pg)
+	}
 	return resourceReadUser(d, meta)
 }
 
@@ -188,6 +551,77 @@ func resourceReadUser(d *schema.ResourceData, meta interface{}) error {
 	if err := updateObjectSchema(resourceActivedirectoryUser().Schema, e, d); err != nil {
 		return err
 	}
+
+	if d.Get("password_version").(int) > 0 {
+		// write-only password is never persisted in state
+		if err := d.Set("password", ""); err != nil {
+			return fmt.Errorf("resourceReadUser: unable to update 'password' err:%w", err)
+		}
+		tracked := d.Get("password_version_last_set").(string)
+		lastSet := d.Get("password_last_set").(string)
+		if isPasswordVersionDrifted(tracked, lastSet) {
+			// password was reset outside of terraform, version is cleared so the reset is planned again
+			c.logger.Info("resourceReadUser: password was set outside of terraform", "dn", e.DN, "tracked", tracked, "password_last_set", lastSet)
+			if err := d.Set("password_version", 0); err != nil {
+				return fmt.Errorf("resourceReadUser: unable to update 'password_version' err:%w", err)
+			}
+		} else if err := d.Set("password_version_last_set", lastSet); err != nil {
+			return fmt.Errorf("resourceReadUser: unable to update 'password_version_last_set' argument value:%v err:%w", lastSet, err)
+		}
+	}
+
+	ce, err := getObjectAttributes(c.conn, e.DN, []string{"msDS-UserPasswordExpiryTimeComputed"})
+	if err != nil {
+		return fmt.Errorf("resourceReadUser: unable to read constructed attributes of user err:%w", err)
+	}
+	pet, err := formatFileTime(ce.GetAttributeValue("msDS-UserPasswordExpiryTimeComputed"))
+	if err != nil {
+		return fmt.Errorf("resourceReadUser: unable to convert 'msDS-UserPasswordExpiryTimeComputed' err:%w", err)
+	}
+	if err := d.Set("password_expiry_time", pet); err != nil {
+		return fmt.Errorf("resourceReadUser: unable to update 'password_expiry_time' argument value:%v err:%w", pet, err)
+	}
+
+	// manager is read only once it's configured or tracked in state, same as other typed attributes
+	if configured := d.Get("manager").(string); configured != "" {
+		manager, err := getManager(c, e, configured)
+		if err != nil {
+			return fmt.Errorf("resourceReadUser: unable to get manager err:%w", err)
+		}
+		if err := d.Set("manager", manager); err != nil {
+			return fmt.Errorf("resourceReadUser: unable to update 'manager' argument value:%v err:%w", manager, err)
+		}
+	}
+
+	lockedOut := false
+	if lt := e.GetAttributeValue("lockoutTime"); lt != "" && lt != "0" {
+		duration, err := getDomainLockoutDuration(c)
+		if err != nil {
+			return fmt.Errorf("resourceReadUser: unable to get domain lockout duration err:%w", err)
+		}
+		if lockedOut, err = isLockedOut(lt, duration, time.Now()); err != nil {
+			return fmt.Errorf("resourceReadUser: unable to get lockout status err:%w", err)
+		}
+	}
+	if err := d.Set("locked_out", lockedOut); err != nil {
+		return fmt.Errorf("resourceReadUser: unable to update 'locked_out' argument value:%v err:%w", lockedOut, err)
+	}
+
+	ccp, err := getCannotChangePassword(c.conn, e.DN)
+	if err != nil {
+		return fmt.Errorf("resourceReadUser: unable to read 'cannot_change_password' err:%w", err)
+	}
+	if err := d.Set("cannot_change_password", ccp); err != nil {
+		return fmt.Errorf("resourceReadUser: unable to update 'cannot_change_password' argument value:%v err:%w", ccp, err)
+	}
+
+	pg, err := getPrimaryGroup(c, e, d.Get("primary_group").(string))
+	if err != nil {
+		return fmt.Errorf("resourceReadUser: unable to get primary group err:%w", err)
+	}
+	if err := d.Set("primary_group", pg); err != nil {
+		return fmt.Errorf("resourceReadUser: unable to update 'primary_group' argument value:%v err:%w", pg, err)
+	}
 	return nil
 }
 
@@ -239,6 +673,12 @@ func resourceUpdateUser(d *schema.ResourceData, meta interface{}) error {
 				return fmt.Errorf("resourceUpdateUser: unable to set account Disabled Flag for  userAccountControl value:%v ,err:%w", uac, err)
 			}
 		}
+	}
+	uac, err = setUACFlagArguments(d, uac, true)
+	if err != nil {
+		return fmt.Errorf("resourceUpdateUser: unable to set userAccountControl flags of typed arguments value:%v ,err:%w", uac, err)
+	}
+	if uac != d.Get("user_account_control").(string) {
 		modReq.Replace("userAccountControl", []string{uac})
 		c.logger.Debug("resourceUpdateUser: updating 'userAccountControl'", "new", uac)
 	}
@@ -273,19 +713,135 @@ func resourceUpdateUser(d *schema.ResourceData, meta interface{}) error {
 		}
 		c.logger.Debug("resourceUpdateUser: updating 'last_name'", "new", d.Get("last_name").(string))
 	}
+	for arg, attr := range userStringAttributes {
+		if !d.HasChange(arg) {
+			continue
+		}
+		if d.Get(arg).(string) == "" {
+			modReq.Replace(attr, []string{})
+		} else {
+			modReq.Replace(attr, []string{d.Get(arg).(string)})
+		}
+		c.logger.Debug("resourceUpdateUser: updating '"+arg+"'", "new", d.Get(arg).(string))
+	}
+	if d.HasChange("logon_hours") {
+		logonHours, err := expandLogonHours(d.Get("logon_hours").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to convert logon_hours err:%w", err)
+		}
+		if logonHours == nil {
+			modReq.Replace("logonHours", []string{})
+		} else {
+			modReq.Replace("logonHours", []string{string(logonHours)})
+		}
+		c.logger.Debug("resourceUpdateUser: updating 'logon_hours'", "new", fmt.Sprintf("%x", logonHours))
+	}
+	if d.HasChange("user_workstations") {
+		if v := d.Get("user_workstations").(*schema.Set); v.Len() == 0 {
+			modReq.Replace("userWorkstations", []string{})
+		} else {
+			modReq.Replace("userWorkstations", []string{strings.Join(setToStringList(v), ",")})
+		}
+		c.logger.Debug("resourceUpdateUser: updating 'user_workstations'", "new", d.Get("user_workstations").(*schema.Set).List())
+	}
+	if d.HasChange("country") {
+		values, err := countryAttributeValues(d.Get("country").(string))
+		if err != nil {
+			return fmt.Errorf("resourceUpdateUser: %w", err)
+		}
+		for _, attr := range countryAttributes {
+			modReq.Replace(attr, values[attr])
+		}
+		c.logger.Debug("resourceUpdateUser: updating 'country'", "new", d.Get("country").(string))
+	}
+	if d.HasChange("manager") {
+		if m := d.Get("manager").(string); m == "" {
+			modReq.Replace("manager", []string{})
+		} else {
+			managerDN, err := getObjectDN(c, m)
+			if err != nil {
+				return fmt.Errorf("resourceUpdateUser: unable to find manager:%s err:%w", m, err)
+			}
+			modReq.Replace("manager", []string{managerDN})
+		}
+		c.logger.Debug("resourceUpdateUser: updating 'manager'", "new", d.Get("manager").(string))
+	}
 	if d.HasChange("user_principal_name") {
 		modReq.Replace("userPrincipalName", []string{d.Get("user_principal_name").(string)})
 		c.logger.Debug("resourceUpdateUser: updating 'userPrincipalName'", "new", d.Get("user_principal_name").(string))
 	}
-	if d.HasChange("password") {
+	passwordReset := false
+	generatedPassword := ""
+	oldPassword, newPassword := "", ""
+	if d.HasChange("password") && !d.Get("generate_password").(bool) {
 		if d.Get("password").(string) == "" {
 			return fmt.Errorf("once set user password cannot be unset or it cant be empty")
 		}
-		pwdEncoded, err := encodePassword(d.Get("password").(string))
+		o, n := d.GetChange("password")
+		oldPassword, newPassword = o.(string), n.(string)
+	}
+	if d.Get("generate_password").(bool) && d.HasChanges("generate_password", "password_rotation_trigger") {
+		generatedPassword, err = generateUserPassword(c, d)
+		if err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to generate password err: %w", err)
+		}
+		o, _ := d.GetChange("generated_password")
+		oldPassword, newPassword = o.(string), generatedPassword
+		c.logger.Debug("resourceUpdateUser: resetting generated password")
+	}
+	if newPassword != "" {
+		if err := c.requireConfidentialConn(); err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to set user password err: %w", err)
+		}
+		if d.Get("password_change_mode").(string) == passwordChangeModeChange {
+			if oldPassword == "" {
+				return fmt.Errorf("resourceUpdateUser: password_change_mode 'change' requires current password in state")
+			}
+			pwdReq, err := passwordChangeRequest(modReq.DN, oldPassword, newPassword)
+			if err != nil {
+				return fmt.Errorf("resourceUpdateUser: unable to create password change request err:%w", err)
+			}
+			// password change is sent before other changes, it would clear pwdLastSet=0 set by them
+			if err := c.conn.Modify(pwdReq); err != nil {
+				return fmt.Errorf("resourceUpdateUser: unable to change user password err:%w", err)
+			}
+			c.logger.Info("resourceUpdateUser: password changed", "dn", modReq.DN)
+			if generatedPassword != "" {
+				if err := d.Set("generated_password", generatedPassword); err != nil {
+					return fmt.Errorf("resourceUpdateUser: unable to update 'generated_password' err:%w", err)
+				}
+			}
+		} else {
+			pwdEncoded, err := encodePassword(newPassword)
+			if err != nil {
+				return fmt.Errorf("unable to encode user password err:%w", err)
+			}
+			modReq.Replace("unicodePwd", []string{pwdEncoded})
+		}
+		passwordReset = true
+	}
+	// password reset clears 'must change password' so it's set again after password change
+	if d.HasChange("change_password_at_next_logon") || (passwordReset && d.Get("change_password_at_next_logon").(bool)) {
+		if d.Get("change_password_at_next_logon").(bool) {
+			modReq.Replace("pwdLastSet", []string{"0"})
+		} else if d.Get("password_last_set").(string) == "" && !passwordReset {
+			// -1 sets pwdLastSet to current time, only needed if password is currently expired
+			modReq.Replace("pwdLastSet", []string{"-1"})
+		}
+		c.logger.Debug("resourceUpdateUser: updating 'change_password_at_next_logon'", "new", d.Get("change_password_at_next_logon").(bool))
+	}
+	if d.HasChange("unlock_trigger") {
+		// lockoutTime can only be set to 0, writing it to account which isn't locked out has no effect
+		modReq.Replace("lockoutTime", []string{"0"})
+		c.logger.Debug("resourceUpdateUser: unlocking account", "dn", modReq.DN)
+	}
+	if d.HasChange("account_expires") && d.Get("account_expires").(string) != "" {
+		ae, err := accountExpiresFromString(d.Get("account_expires").(string))
 		if err != nil {
-			return fmt.Errorf("unable to encode user password err:%w", err)
+			return fmt.Errorf("resourceUpdateUser: unable to convert account_expires err:%w", err)
 		}
-		modReq.Replace("unicodePwd", []string{pwdEncoded})
+		modReq.Replace("accountExpires", []string{ae})
+		c.logger.Debug("resourceUpdateUser: updating 'account_expires'", "new", d.Get("account_expires").(string))
 	}
 
 	if d.HasChange("attributes") {
@@ -309,9 +865,131 @@ func resourceUpdateUser(d *schema.ResourceData, meta interface{}) error {
 		}
 		c.logger.Debug("resourceUpdateUser: modified", "dn", modReq.DN)
 	}
+	// password of new version is tracked once it's set in AD
+	if passwordReset && d.Get("password_version").(int) > 0 {
+		if err := d.Set("password_version_last_set", ""); err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to update 'password_version_last_set' err:%w", err)
+		}
+	}
+	// generated password is saved only once it's set in AD
+	if generatedPassword != "" {
+		if err := d.Set("generated_password", generatedPassword); err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to update 'generated_password' err:%w", err)
+		}
+	}
+
+	if d.HasChange("cannot_change_password") {
+		if err := setCannotChangePassword(c.conn, modReq.DN, d.Get("cannot_change_password").(bool)); err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to update 'cannot_change_password' err: %w", err)
+		}
+		c.logger.Info("resourceUpdateUser: 'cannot_change_password' updated", "dn", modReq.DN, "new", d.Get("cannot_change_password").(bool))
+	}
+
+	if d.HasChange("primary_group") && d.Get("primary_group").(string) != "" {
+		if err := setPrimaryGroup(c, modReq.DN, d.Get("primary_group").(string)); err != nil {
+			return fmt.Errorf("resourceUpdateUser: unable to set primary group err: %w", err)
+		}
+		c.logger.Info("resourceUpdateUser: primary group updated", "dn", modReq.DN, "primary_group", d.Get("primary_group").(string))
+	}
 	return resourceReadUser(d, meta)
 }
 
+// resourceCustomizeDiffUser makes sure typed arguments are not also set in 'attributes',
+// plans password generation and unlock of the account if 'unlock_trigger' is changed and account is locked out
+// and validates password against the password policy.
+func resourceCustomizeDiffUser(d *schema.ResourceDiff, meta interface{}) error {
+	attributes := d.Get("attributes").(string)
+	for arg, attr := range userStringAttributes {
+		if d.Get(arg).(string) != "" && isAttributeInJSON(attributes, attr) {
+			return fmt.Errorf("resourceCustomizeDiffUser: attribute '%s' is managed by '%s' argument and should not be set in 'attributes'", attr, arg)
+		}
+	}
+	for _, attr := range countryAttributes {
+		if d.Get("country").(string) != "" && isAttributeInJSON(attributes, attr) {
+			return fmt.Errorf("resourceCustomizeDiffUser: attribute '%s' is managed by 'country' argument and should not be set in 'attributes'", attr)
+		}
+	}
+	if _, err := expandLogonHours(d.Get("logon_hours").([]interface{})); err != nil {
+		return fmt.Errorf("resourceCustomizeDiffUser: invalid 'logon_hours' err:%w", err)
+	}
+	if d.Get("home_drive").(string) != "" && d.Get("home_directory").(string) == "" {
+		return fmt.Errorf("resourceCustomizeDiffUser: 'home_drive' requires 'home_directory' to be set")
+	}
+	if ws := strings.Join(setToStringList(d.Get("user_workstations").(*schema.Set)), ","); len(ws) > maxUserWorkstationsLength {
+		return fmt.Errorf("resourceCustomizeDiffUser: 'user_workstations' exceeds limit of %d characters, got: %d", maxUserWorkstationsLength, len(ws))
+	}
+	if d.Get("user_workstations").(*schema.Set).Len() > 0 && isAttributeInJSON(attributes, "userWorkstations") {
+		return fmt.Errorf("resourceCustomizeDiffUser: attribute 'userWorkstations' is managed by 'user_workstations' argument and should not be set in 'attributes'")
+	}
+	if d.Get("manager").(string) != "" && isAttributeInJSON(attributes, "manager") {
+		return fmt.Errorf("resourceCustomizeDiffUser: attribute 'manager' is managed by 'manager' argument and should not be set in 'attributes'")
+	}
+
+	if d.Get("generate_password").(bool) && (d.Id() == "" || d.HasChange("generate_password") || d.HasChange("password_rotation_trigger")) {
+		if err := d.SetNewComputed("generated_password"); err != nil {
+			return fmt.Errorf("resourceCustomizeDiffUser: unable to plan password generation err:%w", err)
+		}
+	}
+	if !d.Get("generate_password").(bool) && d.Get("generated_password").(string) != "" {
+		if err := d.SetNew("generated_password", ""); err != nil {
+			return fmt.Errorf("resourceCustomizeDiffUser: unable to clear generated password err:%w", err)
+		}
+	}
+	if d.Id() != "" && d.HasChange("unlock_trigger") && d.Get("locked_out").(bool) {
+		if err := d.SetNew("locked_out", false); err != nil {
+			return fmt.Errorf("resourceCustomizeDiffUser: unable to plan unlock of account err:%w", err)
+		}
+	}
+	if d.Get("password_change_mode").(string) == passwordChangeModeChange && d.Get("password_version").(int) > 0 {
+		return fmt.Errorf("resourceCustomizeDiffUser: password_change_mode 'change' requires current password which is not stored in state if 'password_version' is set")
+	}
+	if isUserPasswordWritePlanned(d) && !meta.(*ADClient).config.isConfidential() {
+		return fmt.Errorf("resourceCustomizeDiffUser: user password cannot be set err:%w", errConfidentialConnRequired)
+	}
+	if err := validateUserPasswordDiff(d, meta); err != nil {
+		return fmt.Errorf("resourceCustomizeDiffUser: %w", err)
+	}
+	return nil
+}
+
+// isUserPasswordWritePlanned checks if password of the user is set by the planned change.
+func isUserPasswordWritePlanned(d *schema.ResourceDiff) bool {
+	generate := d.Get("generate_password").(bool)
+	if d.Id() == "" {
+		return generate || d.Get("password").(string) != "" || !d.NewValueKnown("password")
+	}
+	if generate {
+		return d.HasChange("generate_password") || d.HasChange("password_rotation_trigger")
+	}
+	return d.HasChange("password")
+}
+
+// validateUserPasswordDiff validates planned password against the password policy applied to the user
+// so that the password is not rejected by AD after the object is created or in the middle of the update.
+func validateUserPasswordDiff(d *schema.ResourceDiff, meta interface{}) error {
+	password := d.Get("password").(string)
+	if password == "" || d.Get("generate_password").(bool) || (d.Id() != "" && !d.HasChange("password")) {
+		return nil
+	}
+	if !d.NewValueKnown("password") || !d.NewValueKnown("sam_account_name") || !d.NewValueKnown("display_name") {
+		return nil
+	}
+	c := meta.(*ADClient)
+	if err := c.initialiseConn(); err != nil {
+		return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+	}
+	defer c.done()
+
+	policy, err := getUserPasswordPolicy(c, d.Id())
+	if err != nil {
+		return fmt.Errorf("unable to get password policy err:%w", err)
+	}
+	if err := validatePassword(password, policy, d.Get("sam_account_name").(string), d.Get("display_name").(string)); err != nil {
+		return fmt.Errorf("invalid 'password' err:%w", err)
+	}
+	return nil
+}
+
 func userSchemaToAddRequest(d *schema.ResourceData) (*ldap.AddRequest, error) {
 	var addReq ldap.AddRequest
 	enabled := d.Get("enabled").(bool)
@@ -321,9 +999,14 @@ func userSchemaToAddRequest(d *schema.ResourceData) (*ldap.AddRequest, error) {
 
 	// set default value for user userAccountControl to NORMAL_ACCOUNT
 	uac := "512"
+	password := userPassword(d)
+	// user without password requires PASSWD_NOTREQD, this matches default value set by AD
+	if password == "" {
+		uac = "544"
+	}
 
 	// make sure user is disbled if password is not set.
-	if d.Get("password").(string) == "" && enabled {
+	if password == "" && enabled {
 		return nil, fmt.Errorf("user cannot be enabled if password is not set. either set password or specify argument `enabled = false`")
 	}
 
@@ -353,6 +1036,10 @@ func userSchemaToAddRequest(d *schema.ResourceData) (*ldap.AddRequest, error) {
 			return nil, fmt.Errorf("unable to unsetaccountDisabledFlag for userAccountControl value:%v ,err:%w", uac, err)
 		}
 	}
+	uac, err = setUACFlagArguments(d, uac, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set userAccountControl flags of typed arguments value:%v ,err:%w", uac, err)
+	}
 
 	if d.Get("first_name").(string) != "" {
 		addReq.Attribute("givenName", []string{d.Get("first_name").(string)})
@@ -363,15 +1050,49 @@ func userSchemaToAddRequest(d *schema.ResourceData) (*ldap.AddRequest, error) {
 	if d.Get("description").(string) != "" {
 		addReq.Attribute("description", []string{d.Get("description").(string)})
 	}
+	for arg, attr := range userStringAttributes {
+		if v := d.Get(arg).(string); v != "" {
+			addReq.Attribute(attr, []string{v})
+		}
+	}
+	logonHours, err := expandLogonHours(d.Get("logon_hours").([]interface{}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert logon_hours err:%w", err)
+	}
+	if logonHours != nil {
+		addReq.Attribute("logonHours", []string{string(logonHours)})
+	}
+	if v := d.Get("user_workstations").(*schema.Set); v.Len() > 0 {
+		addReq.Attribute("userWorkstations", []string{strings.Join(setToStringList(v), ",")})
+	}
+	if v := d.Get("country").(string); v != "" {
+		values, err := countryAttributeValues(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, attr := range countryAttributes {
+			addReq.Attribute(attr, values[attr])
+		}
+	}
 
-	if d.Get("password").(string) != "" {
-		pwdEncoded, err := encodePassword(d.Get("password").(string))
+	if password != "" {
+		pwdEncoded, err := encodePassword(password)
 		if err != nil {
 			return nil, fmt.Errorf("unable to encode user password err:%w", err)
 		}
 		addReq.Attribute("unicodePwd", []string{pwdEncoded})
-		addReq.Attribute("userAccountControl", []string{uac})
 	}
+	if d.Get("change_password_at_next_logon").(bool) {
+		addReq.Attribute("pwdLastSet", []string{"0"})
+	}
+	if v := d.Get("account_expires").(string); v != "" {
+		ae, err := accountExpiresFromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert account_expires err:%w", err)
+		}
+		addReq.Attribute("accountExpires", []string{ae})
+	}
+	addReq.Attribute("userAccountControl", []string{uac})
 
 	addReq.Attribute("name", []string{name})
 	addReq.Attribute("cn", []string{name})
@@ -381,6 +1102,44 @@ func userSchemaToAddRequest(d *schema.ResourceData) (*ldap.AddRequest, error) {
 	return &addReq, nil
 }
 
+// userPassword returns configured password or generated password if generate_password is true.
+func userPassword(d *schema.ResourceData) string {
+	if d.Get("generate_password").(bool) {
+		return d.Get("generated_password").(string)
+	}
+	return d.Get("password").(string)
+}
+
+// generateUserPassword generates password which meets the password policy applied to the user.
+func generateUserPassword(c *ADClient, d *schema.ResourceData) (string, error) {
+	policy, err := getUserPasswordPolicy(c, d.Id())
+	if err != nil {
+		return "", err
+	}
+	length := passwordGenerationLength
+	if policy.minLength > length {
+		length = policy.minLength
+	}
+	return generatePassword(length, policy.complexity, d.Get("sam_account_name").(string), d.Get("display_name").(string))
+}
+
+// passwordChangeRequest returns modify request of user password change, old password is deleted and
+// new password is added in the same request so that AD enforces password history and policy.
+func passwordChangeRequest(dn, oldPassword, newPassword string) (*ldap.ModifyRequest, error) {
+	oldEncoded, err := encodePassword(oldPassword)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode old password err:%w", err)
+	}
+	newEncoded, err := encodePassword(newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode new password err:%w", err)
+	}
+	modReq := ldap.NewModifyRequest(dn, nil)
+	modReq.Delete("unicodePwd", []string{oldEncoded})
+	modReq.Add("unicodePwd", []string{newEncoded})
+	return modReq, nil
+}
+
 func encodePassword(pass string) (string, error) {
 	// https://github.com/go-ldap/ldap/issues/106
 	utf16 := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
@@ -1,8 +1,13 @@
 package activedirectory
 
 import (
+	"crypto/tls"
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-ldap/ldap/v3"
@@ -77,6 +82,7 @@ func TestAccUser_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user1", "first_name", ""),
 					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user1", "last_name", ""),
 					resource.TestCheckNoResourceAttr("activedirectory_user.test_acc_user1", "password"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user1", "locked_out", "false"),
 				),
 			},
 		},
@@ -160,6 +166,768 @@ func TestAccUser_Advanced(t *testing.T) {
 	})
 }
 
+func TestAccUser_AccountFlags(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with account flags set
+				Config: testAccResourceADUserFlagsTestData("true", "true", "true", "false", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user3"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "password_never_expires", "true"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "cannot_change_password", "true"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "dont_require_preauth", "true"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "not_delegated", "false"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "password_not_required", "false"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "user_account_control", "4260352"),
+				),
+			}, {
+				// flip account flags
+				Config: testAccResourceADUserFlagsTestData("false", "false", "false", "true", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user3"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "password_never_expires", "false"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "cannot_change_password", "false"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "dont_require_preauth", "false"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "not_delegated", "true"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user3", "user_account_control", "1049088"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserFlagsTestData(neverExpires, cannotChange, noPreauth, notDelegated, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user3" {
+	name                   = "test_acc_user3"
+	sam_account_name       = "test_acc_user3"
+	user_principal_name    = "test_acc_user3@%s"
+	password               = "secretPassword!123"
+	base_ou_dn             = "%s"
+	password_never_expires = %s
+	cannot_change_password = %s
+	dont_require_preauth   = %s
+	not_delegated          = %s
+}
+`, domain, baseOU, neverExpires, cannotChange, noPreauth, notDelegated)
+}
+
+func TestAccUser_AccountExpiry(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with expiry date and password change required
+				Config: testAccResourceADUserExpiryTestData("2035-01-01T02:00:00+02:00", "true", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user4"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user4", "account_expires", "2035-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user4", "change_password_at_next_logon", "true"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user4", "password_last_set", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user4", "last_logon", ""),
+				),
+			}, {
+				// remove expiry date and password change requirement
+				Config: testAccResourceADUserExpiryTestData("never", "false", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user4"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user4", "account_expires", "never"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user4", "change_password_at_next_logon", "false"),
+					resource.TestCheckResourceAttrSet("activedirectory_user.test_acc_user4", "password_last_set"),
+					resource.TestCheckResourceAttrSet("activedirectory_user.test_acc_user4", "password_expiry_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserExpiryTestData(expires, changePassword, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user4" {
+	name                          = "test_acc_user4"
+	sam_account_name              = "test_acc_user4"
+	user_principal_name           = "test_acc_user4@%s"
+	password                      = "secretPassword!123"
+	base_ou_dn                    = "%s"
+	account_expires               = "%s"
+	change_password_at_next_logon = %s
+}
+`, domain, baseOU, expires, changePassword)
+}
+
+func TestAccUser_Unlock(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if testAccDomainLockoutThreshold(t) == 0 {
+				t.Skip("accounts are never locked out, lockoutThreshold of the domain is 0")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create enabled user with unlock trigger
+				Config: testAccResourceADUserUnlockTestData("1", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user10"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user10", "locked_out", "false"),
+				),
+			}, {
+				// account locked out by failed logons is not unlocked while trigger is unchanged
+				PreConfig: func() { testAccLockOutUser(t, "test_acc_user10@"+domain) },
+				Config:    testAccResourceADUserUnlockTestData("1", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user10", "locked_out", "true"),
+				),
+			}, {
+				// changed trigger unlocks the account
+				Config: testAccResourceADUserUnlockTestData("2", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user10", "locked_out", "false"),
+					testAccCheckUserRemoteLockoutTime("activedirectory_user.test_acc_user10", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserUnlockTestData(unlock, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user10" {
+	name                = "test_acc_user10"
+	sam_account_name    = "test_acc_user10"
+	user_principal_name = "test_acc_user10@%s"
+	password            = "secretPassword!123"
+	base_ou_dn          = "%s"
+	unlock_trigger = {
+		unlock = "%s"
+	}
+}
+`, domain, baseOU, unlock)
+}
+
+// testAccDomainLockoutThreshold returns number of failed logons which lock out accounts of the domain
+func testAccDomainLockoutThreshold(t *testing.T) int {
+	c := testAccProvider.Meta().(*ADClient)
+	if err := c.initialiseConn(); err != nil {
+		t.Fatalf("unable to connect to LDAP server err:%v", err)
+	}
+	defer c.done()
+
+	e, err := getObjectAttributes(c.conn, c.config.domainDN, []string{"lockoutThreshold"})
+	if err != nil {
+		t.Fatalf("unable to read domain object err:%v", err)
+	}
+	threshold, err := strconv.Atoi(e.GetAttributeValue("lockoutThreshold"))
+	if err != nil {
+		t.Fatalf("unable to parse lockoutThreshold err:%v", err)
+	}
+	return threshold
+}
+
+// testAccLockOutUser locks out the account by binding with wrong password until lockoutThreshold is reached
+func testAccLockOutUser(t *testing.T, upn string) {
+	c := testAccProvider.Meta().(*ADClient)
+	conn, err := ldap.DialURL(c.config.serverURL, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: c.config.insecureTLS}))
+	if err != nil {
+		t.Fatalf("unable to connect to LDAP server err:%v", err)
+	}
+	defer conn.Close()
+
+	for i := 0; i < testAccDomainLockoutThreshold(t); i++ {
+		if err := conn.Bind(upn, "wrongPassword!123"); err == nil {
+			t.Fatalf("bind of %s with wrong password succeeded", upn)
+		}
+	}
+}
+
+// testAccCheckUserRemoteLockoutTime checks lockoutTime attribute of remote user
+func testAccCheckUserRemoteLockoutTime(name, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		c := testAccProvider.Meta().(*ADClient)
+		if err := c.initialiseConn(); err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		e, err := getObjectAttributes(c.conn, rs.Primary.Attributes["dn"], []string{"lockoutTime"})
+		if err != nil {
+			return err
+		}
+		if got := e.GetAttributeValue("lockoutTime"); got != want {
+			return fmt.Errorf("lockoutTime of %s is %s, want %s", rs.Primary.Attributes["dn"], got, want)
+		}
+		return nil
+	}
+}
+
+func TestAccUser_PrimaryGroup(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with primary group set by dn
+				Config: testAccResourceADUserPrimaryGroupTestData("activedirectory_group.test_acc_group_pg3.dn", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user11"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user11", "primary_group", "CN=test_acc_group_pg3,"+baseOU),
+					testAccCheckPrimaryGroupRemote("activedirectory_user.test_acc_user11", "activedirectory_group.test_acc_group_pg3"),
+				),
+			}, {
+				// change primary group using SID
+				Config: testAccResourceADUserPrimaryGroupTestData("activedirectory_group.test_acc_group_pg4.sid", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user11"),
+					resource.TestCheckResourceAttrPair("activedirectory_user.test_acc_user11", "primary_group", "activedirectory_group.test_acc_group_pg4", "sid"),
+					testAccCheckPrimaryGroupRemote("activedirectory_user.test_acc_user11", "activedirectory_group.test_acc_group_pg4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserPrimaryGroupTestData(primaryGroup, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_group" "test_acc_group_pg3" {
+	name             = "test_acc_group_pg3"
+	sam_account_name = "test_acc_group_pg3"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_group" "test_acc_group_pg4" {
+	name             = "test_acc_group_pg4"
+	sam_account_name = "test_acc_group_pg4"
+	base_ou_dn       = "%s"
+}
+
+resource "activedirectory_user" "test_acc_user11" {
+	enabled             = false
+	name                = "test_acc_user11"
+	sam_account_name    = "test_acc_user11"
+	user_principal_name = "test_acc_user11@%s"
+	base_ou_dn          = "%s"
+	primary_group       = %s
+}
+`, baseOU, baseOU, domain, baseOU, primaryGroup)
+}
+
+// testAccCheckPrimaryGroupRemote checks primaryGroupID of remote object is RID of the group
+func testAccCheckPrimaryGroupRemote(name, group string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		gs, ok := s.RootModule().Resources[group]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", group)
+		}
+		c := testAccProvider.Meta().(*ADClient)
+		if err := c.initialiseConn(); err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		e, err := getObjectAttributes(c.conn, rs.Primary.Attributes["dn"], []string{"primaryGroupID"})
+		if err != nil {
+			return err
+		}
+		sid := gs.Primary.Attributes["sid"]
+		if rid := sid[strings.LastIndex(sid, "-")+1:]; e.GetAttributeValue("primaryGroupID") != rid {
+			return fmt.Errorf("primaryGroupID of %s is %s, want RID of %s", rs.Primary.Attributes["dn"], e.GetAttributeValue("primaryGroupID"), sid)
+		}
+		return nil
+	}
+}
+
+func TestAccUser_OrganisationalAttributes(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with organisational attributes and manager referenced by dn
+				Config: testAccResourceADUserOrgTestData(`
+	mail             = "test_acc_user5@`+domain+`"
+	display_name     = "Test User5"
+	initials         = "TU"
+	title            = "Engineer"
+	department       = "IT"
+	company          = "example"
+	office           = "HQ"
+	telephone_number = "+1 555 0100"
+	mobile           = "+1 555 0101"
+	employee_id      = "1005"
+	employee_type    = "Contractor"
+	street           = "1 Main Street"
+	city             = "Springfield"
+	state            = "IL"
+	postal_code      = "62701"
+	country          = "us"
+	home_page        = "https://example.com"
+	manager          = activedirectory_user.test_acc_manager.dn`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user5"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "mail", "test_acc_user5@"+domain),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "display_name", "Test User5"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "office", "HQ"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "employee_type", "Contractor"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "city", "Springfield"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "country", "US"),
+					resource.TestCheckResourceAttrPair("activedirectory_user.test_acc_user5", "manager", "activedirectory_user.test_acc_manager", "dn"),
+				),
+			}, {
+				// clear attributes, change country and reference manager by GUID
+				Config: testAccResourceADUserOrgTestData(`
+	display_name = "Test User5"
+	country      = "GB"
+	manager      = activedirectory_user.test_acc_manager.id`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user5"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "mail", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "title", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "city", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "country", "GB"),
+					resource.TestCheckResourceAttrPair("activedirectory_user.test_acc_user5", "manager", "activedirectory_user.test_acc_manager", "id"),
+				),
+			}, {
+				// clear country and manager
+				Config: testAccResourceADUserOrgTestData("", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user5"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "display_name", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "country", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "manager", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_UnmanagedAttributes(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	dn := "CN=test_acc_user5," + baseOU
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceADUserOrgTestData(`display_name = "Test User5"`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user5"),
+				),
+			}, {
+				// attributes set outside of terraform are neither read nor cleared while arguments aren't configured
+				PreConfig: func() {
+					testAccSetRemoteAttributes(t, dn, map[string][]string{
+						"title":   {"External"},
+						"c":       {"DE"},
+						"manager": {"CN=test_acc_manager," + baseOU},
+					})
+				},
+				Config: testAccResourceADUserOrgTestData(`display_name = "Test User5"`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "title", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "country", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "manager", ""),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user5", "title", "External"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user5", "c", "DE"),
+				),
+			}, {
+				// configured argument takes over the attribute
+				Config: testAccResourceADUserOrgTestData(`
+	display_name = "Test User5"
+	title        = "Engineer"`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "title", "Engineer"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user5", "title", "Engineer"),
+				),
+			}, {
+				// removed argument which is tracked in state clears the attribute
+				Config: testAccResourceADUserOrgTestData(`display_name = "Test User5"`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user5", "title", ""),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user5", "title", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserOrgTestData(arguments, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_manager" {
+	enabled             = false
+	name                = "test_acc_manager"
+	sam_account_name    = "test_acc_manager"
+	user_principal_name = "test_acc_manager@%s"
+	base_ou_dn          = "%s"
+}
+resource "activedirectory_user" "test_acc_user5" {
+	enabled             = false
+	name                = "test_acc_user5"
+	sam_account_name    = "test_acc_user5"
+	user_principal_name = "test_acc_user5@%s"
+	base_ou_dn          = "%s"
+	%s
+}
+`, domain, baseOU, domain, baseOU, arguments)
+}
+
+func TestAccUser_Profile(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with profile and home directory settings
+				Config: testAccResourceADUserProfileTestData(`
+	home_directory    = "\\\\fileserver\\home\\test_acc_user6"
+	home_drive        = "H:"
+	profile_path      = "\\\\fileserver\\profiles\\test_acc_user6"
+	script_path       = "logon.bat"
+	user_workstations = ["WKS001", "WKS002"]`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user6"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "home_directory", `\\fileserver\home\test_acc_user6`),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "home_drive", "H:"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "profile_path", `\\fileserver\profiles\test_acc_user6`),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "script_path", "logon.bat"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "user_workstations.#", "2"),
+				),
+			}, {
+				// clear settings
+				Config: testAccResourceADUserProfileTestData("", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user6"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "home_directory", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "home_drive", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "profile_path", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "script_path", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "user_workstations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_UnmanagedProfile(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	dn := "CN=test_acc_user6," + baseOU
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceADUserProfileTestData("", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user6"),
+				),
+			}, {
+				// settings made outside of terraform are neither read nor cleared while arguments aren't configured
+				PreConfig: func() {
+					testAccSetRemoteAttributes(t, dn, map[string][]string{
+						"homeDirectory":    {`\\fileserver\home\test_acc_user6`},
+						"homeDrive":        {"H:"},
+						"profilePath":      {`\\fileserver\profiles\test_acc_user6`},
+						"scriptPath":       {"logon.bat"},
+						"userWorkstations": {"WKS001,WKS002"},
+					})
+				},
+				Config: testAccResourceADUserProfileTestData("", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "home_directory", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "home_drive", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "profile_path", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "script_path", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "user_workstations.#", "0"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user6", "homeDrive", "H:"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user6", "scriptPath", "logon.bat"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user6", "userWorkstations", "WKS001,WKS002"),
+				),
+			}, {
+				// configured arguments take over the attributes
+				Config: testAccResourceADUserProfileTestData(`
+	script_path       = "logon2.bat"
+	user_workstations = ["WKS003"]`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user6", "scriptPath", "logon2.bat"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user6", "userWorkstations", "WKS003"),
+					testAccCheckRemoteAttribute("activedirectory_user.test_acc_user6", "homeDrive", "H:"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserProfileTestData(arguments, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user6" {
+	enabled             = false
+	name                = "test_acc_user6"
+	sam_account_name    = "test_acc_user6"
+	user_principal_name = "test_acc_user6@%s"
+	base_ou_dn          = "%s"
+	%s
+}
+`, domain, baseOU, arguments)
+}
+
+func TestAccUser_LogonHours(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with logon hours schedule in non UTC time zone
+				Config: testAccResourceADUserProfileTestData(`
+	logon_hours {
+		timezone = "+02:00"
+		schedule {
+			day   = "monday"
+			start = 0
+			end   = 8
+		}
+		schedule {
+			day   = "monday"
+			start = 18
+			end   = 24
+		}
+		schedule {
+			day   = "saturday"
+			start = 9
+			end   = 13
+		}
+	}`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user6"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "logon_hours.#", "1"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "logon_hours.0.timezone", "+02:00"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "logon_hours.0.schedule.#", "3"),
+				),
+			}, {
+				// deny all logon hours
+				Config: testAccResourceADUserProfileTestData(`
+	logon_hours {}`, domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user6"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "logon_hours.#", "1"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "logon_hours.0.schedule.#", "0"),
+				),
+			}, {
+				// remove logon hours restriction
+				Config: testAccResourceADUserProfileTestData("", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user6"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user6", "logon_hours.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_GeneratePassword(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	var firstPassword string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create enabled user with generated password
+				Config: testAccResourceADUserGeneratePasswordTestData("1", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user7"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user7", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("activedirectory_user.test_acc_user7", "generated_password"),
+					testAccGetResourceAttr("activedirectory_user.test_acc_user7", "generated_password", &firstPassword),
+				),
+			}, {
+				// rotate password
+				Config: testAccResourceADUserGeneratePasswordTestData("2", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user7"),
+					resource.TestCheckResourceAttrSet("activedirectory_user.test_acc_user7", "generated_password"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["activedirectory_user.test_acc_user7"]
+						if rs.Primary.Attributes["generated_password"] == firstPassword {
+							return fmt.Errorf("generated password is not rotated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccGetResourceAttr(name, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+func testAccResourceADUserGeneratePasswordTestData(rotation, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user7" {
+	name                = "test_acc_user7"
+	sam_account_name    = "test_acc_user7"
+	user_principal_name = "test_acc_user7@%s"
+	base_ou_dn          = "%s"
+	generate_password   = true
+	password_rotation_trigger = {
+		rotation = "%s"
+	}
+}
+`, domain, baseOU, rotation)
+}
+
+func TestAccUser_PasswordVersion(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create user with write-only password
+				Config: testAccResourceADUserPasswordVersionTestData("Terraform@Test#1", "1", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user8"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user8", "password", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user8", "password_version", "1"),
+					resource.TestCheckResourceAttrSet("activedirectory_user.test_acc_user8", "password_version_last_set"),
+				),
+			}, {
+				// password change without version change is not planned
+				Config:   testAccResourceADUserPasswordVersionTestData("Terraform@Test#2", "1", domain, baseOU),
+				PlanOnly: true,
+			}, {
+				// version change resets password
+				Config: testAccResourceADUserPasswordVersionTestData("Terraform@Test#2", "2", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user8"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user8", "password", ""),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user8", "password_version", "2"),
+					resource.TestCheckResourceAttrSet("activedirectory_user.test_acc_user8", "password_version_last_set"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_PasswordPolicy(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// password which contains sAMAccountName is rejected at plan time
+				Config:      testAccResourceADUserPasswordVersionTestData("test_acc_user8#A1", "1", domain, baseOU),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("password contains sAMAccountName"),
+			},
+		},
+	})
+}
+
+func TestAccUser_PasswordChange(t *testing.T) {
+	baseOU := os.Getenv("AD_BASE_OU")
+	domain := os.Getenv("AD_DOMAIN")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceADUserPasswordChangeTestData("Terraform@Test#1", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user9"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user9", "password_change_mode", "change"),
+				),
+			}, {
+				// password is changed using the current password from state
+				Config: testAccResourceADUserPasswordChangeTestData("Terraform@Test#2", domain, baseOU),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRemoteAttr("activedirectory_user.test_acc_user9"),
+					resource.TestCheckResourceAttr("activedirectory_user.test_acc_user9", "password", "Terraform@Test#2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceADUserPasswordChangeTestData(password, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user9" {
+	name                 = "test_acc_user9"
+	sam_account_name     = "test_acc_user9"
+	user_principal_name  = "test_acc_user9@%s"
+	base_ou_dn           = "%s"
+	password             = "%s"
+	password_change_mode = "change"
+}
+`, domain, baseOU, password)
+}
+
+func testAccResourceADUserPasswordVersionTestData(password, version, domain, baseOU string) string {
+	return fmt.Sprintf(`
+resource "activedirectory_user" "test_acc_user8" {
+	name                = "test_acc_user8"
+	sam_account_name    = "test_acc_user8"
+	user_principal_name = "test_acc_user8@%s"
+	base_ou_dn          = "%s"
+	password            = "%s"
+	password_version    = %s
+}
+`, domain, baseOU, password, version)
+}
+
 func testAccResourceADUserTestData(num, enabled, first, last, name, sam, upn, pass, ou, description, attributes, baseOU string) string {
 	return fmt.Sprintf(`
 resource "activedirectory_user" "test_acc_user%s" {
@@ -192,3 +960,60 @@ func testAccCheckUserDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func Test_passwordChangeRequest(t *testing.T) {
+	modReq, err := passwordChangeRequest("CN=user,DC=example,DC=com", "old", "new")
+	if err != nil {
+		t.Fatalf("passwordChangeRequest() error = %v", err)
+	}
+	oldEncoded, _ := encodePassword("old")
+	newEncoded, _ := encodePassword("new")
+	want := []ldap.Change{
+		{Operation: ldap.DeleteAttribute, Modification: ldap.PartialAttribute{Type: "unicodePwd", Vals: []string{oldEncoded}}},
+		{Operation: ldap.AddAttribute, Modification: ldap.PartialAttribute{Type: "unicodePwd", Vals: []string{newEncoded}}},
+	}
+	if !reflect.DeepEqual(modReq.Changes, want) {
+		t.Errorf("passwordChangeRequest() Got = %v, want %v", modReq.Changes, want)
+	}
+}
+
+// testAccSetRemoteAttributes replaces attributes of remote object, it's used to change objects outside of terraform
+func testAccSetRemoteAttributes(t *testing.T, dn string, attributes map[string][]string) {
+	c := testAccProvider.Meta().(*ADClient)
+	if err := c.initialiseConn(); err != nil {
+		t.Fatalf("unable to connect to LDAP server err:%v", err)
+	}
+	defer c.done()
+
+	modReq := ldap.NewModifyRequest(dn, []ldap.Control{})
+	for name, values := range attributes {
+		modReq.Replace(name, values)
+	}
+	if err := c.conn.Modify(modReq); err != nil {
+		t.Fatalf("unable to update attributes of %s err:%v", dn, err)
+	}
+}
+
+// testAccCheckRemoteAttribute checks single valued attribute of remote object
+func testAccCheckRemoteAttribute(name, attribute, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		c := testAccProvider.Meta().(*ADClient)
+		if err := c.initialiseConn(); err != nil {
+			return fmt.Errorf("unable to connect to LDAP server err:%w", err)
+		}
+		defer c.done()
+
+		e, err := getObjectAttributes(c.conn, rs.Primary.Attributes["dn"], []string{attribute})
+		if err != nil {
+			return err
+		}
+		if got := e.GetAttributeValue(attribute); got != want {
+			return fmt.Errorf("%s of %s is %s, want %s", attribute, rs.Primary.Attributes["dn"], got, want)
+		}
+		return nil
+	}
+}